@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/ethpandaops/syncoor/pkg/synctest"
+	"github.com/ethpandaops/syncoor/pkg/sysinfo"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var ErrAgentServerRequired = errors.New("--server is required")
+
+// agentRetryDelay is how long the agent waits after failing to poll for jobs
+const agentRetryDelay = 10 * time.Second
+
+// AgentConfig holds the settings of the agent command
+type AgentConfig struct {
+	ServerURL       string
+	ServerAuth      string
+	RunnerID        string
+	RunnerLabels    []string
+	Capacity        int
+	PollWait        time.Duration
+	ReportDir       string
+	Storage         storageFlags
+	CheckInterval   time.Duration
+	RunTimeout      time.Duration
+	EthereumPackage string
+	ClientLogs      bool
+}
+
+// agent runs jobs queued on the server
+type agent struct {
+	log      logrus.FieldLogger
+	cfg      *AgentConfig
+	client   *reporting.Client
+	storage  report.Storage // Nil for local reports
+	runnerID string
+	labels   map[string]string
+}
+
+func NewAgentCommand() *cobra.Command {
+	cfg := &AgentConfig{}
+
+	cmd := &cobra.Command{
+		Use:   "agent",
+		Short: "Run sync tests queued on the server",
+		Long: `Registers with the centralized server as a runner and long-polls it for jobs matching
+the runner labels. Jobs are run like the sync command and their results reported back.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+
+			return runAgent(ctx, cfg)
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.ServerURL, "server", "", "Centralized server URL (e.g., https://api.syncoor.example)")
+	cmd.Flags().StringVar(&cfg.ServerAuth, "server-auth", "", "Bearer token for server authentication")
+	cmd.Flags().StringVar(&cfg.RunnerID, "runner-id", "", "Identity of this runner on the server (defaults to the hostname)")
+	cmd.Flags().StringSliceVar(&cfg.RunnerLabels, "runner-label", []string{},
+		"Runner labels in key=value format, jobs are only taken if the runner has all their runner labels (can be used multiple times)")
+	cmd.Flags().IntVar(&cfg.Capacity, "capacity", 1, "Number of jobs to run at once")
+	cmd.Flags().DurationVar(&cfg.PollWait, "poll-wait", 30*time.Second, "How long each poll waits for a job to be queued")
+	cmd.Flags().StringVar(&cfg.ReportDir, "report-dir", "./reports", "Directory to save reports (defaults to ./reports)")
+	cfg.Storage.register(cmd)
+	cmd.Flags().DurationVar(&cfg.CheckInterval, "check-interval", 10*time.Second, "Interval between sync status checks")
+	cmd.Flags().DurationVar(&cfg.RunTimeout, "run-timeout", 60*time.Minute, "Timeout for jobs that don't set their own")
+	cmd.Flags().StringVar(&cfg.EthereumPackage, "ethereum-package", "github.com/ethpandaops/ethereum-package@main",
+		"Ethereum package repository and version (e.g., github.com/ethpandaops/ethereum-package@main)")
+	cmd.Flags().BoolVar(&cfg.ClientLogs, "client-logs", false, "Output EL and CL client logs to stdout")
+
+	return cmd
+}
+
+func runAgent(ctx context.Context, cfg *AgentConfig) error {
+	if cfg.ServerURL == "" {
+		return ErrAgentServerRequired
+	}
+
+	log := logrus.WithField("component", "agent")
+
+	labels, err := parseLabels(cfg.RunnerLabels)
+	if err != nil {
+		return err
+	}
+
+	a := &agent{
+		log:    log,
+		cfg:    cfg,
+		client: reporting.NewClient(cfg.ServerURL, cfg.ServerAuth, log.WithField("component", "reporting")),
+		labels: labels,
+	}
+
+	if !cfg.Storage.isLocal() {
+		if a.storage, err = cfg.Storage.build(cfg.ReportDir); err != nil {
+			return fmt.Errorf("failed to configure report storage: %w", err)
+		}
+	}
+
+	// System info is optional, as for sync runs
+	sysInfoService := sysinfo.NewService(log)
+	sysInfoService.SetSyncoorVersion(Version)
+	systemInfo, err := sysInfoService.GetSystemInfo(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Failed to collect system information")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil && systemInfo != nil {
+		hostname = systemInfo.Hostname
+	}
+	a.runnerID = cfg.RunnerID
+	if a.runnerID == "" {
+		a.runnerID = hostname
+	}
+
+	a.client.Start(ctx)
+	defer a.client.Stop()
+	a.client.StartHeartbeat(ctx, reporting.RunnerHeartbeatRequest{
+		RunnerID:   a.runnerID,
+		Hostname:   hostname,
+		Labels:     labels,
+		Capacity:   cfg.Capacity,
+		SystemInfo: systemInfo,
+	})
+
+	log.WithFields(logrus.Fields{
+		"server":    cfg.ServerURL,
+		"runner_id": a.runnerID,
+		"labels":    labels,
+		"capacity":  cfg.Capacity,
+	}).Info("Agent started, waiting for jobs")
+
+	var wg sync.WaitGroup
+	for i := 0; i < max(cfg.Capacity, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.work(ctx)
+		}()
+	}
+	wg.Wait()
+
+	log.Info("Agent stopped")
+	return nil
+}
+
+// work polls for jobs and runs them one at a time until ctx is cancelled
+func (a *agent) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := a.client.PollJob(ctx, reporting.JobPollRequest{
+			RunnerID: a.runnerID,
+			Labels:   a.labels,
+			Wait:     int64(a.cfg.PollWait.Seconds()),
+		})
+		switch {
+		case errors.Is(err, reporting.ErrNoJob):
+			continue
+		case err != nil:
+			if ctx.Err() == nil {
+				a.log.WithError(err).Warn("Failed to poll for jobs")
+			}
+			select {
+			case <-time.After(agentRetryDelay):
+			case <-ctx.Done():
+			}
+			continue
+		}
+
+		a.runJob(ctx, job)
+	}
+}
+
+// runJob runs a job and reports its result
+func (a *agent) runJob(ctx context.Context, job *reporting.JobAssignment) {
+	log := a.log.WithFields(logrus.Fields{
+		"job_id":    job.JobID,
+		"run_id":    job.RunID,
+		"network":   job.Spec.Network,
+		"el_client": job.Spec.ELClient,
+		"cl_client": job.Spec.CLClient,
+	})
+	log.Info("Running job")
+
+	a.client.AddActiveRun(job.RunID)
+	defer a.client.RemoveActiveRun(job.RunID)
+
+	result := reporting.JobCompleteRequest{RunnerID: a.runnerID, Success: true}
+	err := a.runSyncTest(ctx, job, log)
+	switch {
+	case errors.Is(err, synctest.ErrSyncCancelled):
+		log.WithError(err).Warn("Job cancelled")
+		result.Success = false
+		result.Cancelled = true
+		result.Error = err.Error()
+	case err != nil:
+		log.WithError(err).Error("Job failed")
+		result.Success = false
+		result.Error = err.Error()
+		if ctx.Err() != nil {
+			result.Error = "agent stopped before the job finished"
+		}
+	default:
+		log.Info("Job completed")
+	}
+
+	// Report the result even if the agent is shutting down
+	completeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := a.client.CompleteJob(completeCtx, job.JobID, result); err != nil {
+		log.WithError(err).Error("Failed to report job result")
+	}
+}
+
+// runSyncTest runs a job as the sync command would, reporting to the server as the job's run
+func (a *agent) runSyncTest(ctx context.Context, job *reporting.JobAssignment, log logrus.FieldLogger) error {
+	spec := job.Spec
+
+	runTimeout := a.cfg.RunTimeout
+	if spec.RunTimeout > 0 {
+		runTimeout = time.Duration(spec.RunTimeout) * time.Second
+	}
+
+	config := synctest.Config{
+		CheckInterval:         a.cfg.CheckInterval,
+		RunTimeout:            runTimeout,
+		ELClient:              spec.ELClient,
+		CLClient:              spec.CLClient,
+		ELImage:               spec.ELImage,
+		CLImage:               spec.CLImage,
+		ELExtraArgs:           spec.ELExtraArgs,
+		CLExtraArgs:           spec.CLExtraArgs,
+		ELEnvVars:             spec.ELEnvVars,
+		CLEnvVars:             spec.CLEnvVars,
+		Network:               spec.Network,
+		EnclaveName:           "syncoor-job-" + job.JobID, // Unique, jobs may run concurrently
+		ReportDir:             a.cfg.ReportDir,
+		Labels:                spec.Labels,
+		ServerURL:             a.cfg.ServerURL,
+		ServerAuth:            a.cfg.ServerAuth,
+		RunnerID:              a.runnerID,
+		RunnerLabels:          a.labels,
+		RunID:                 job.RunID,
+		ExternalHeartbeat:     true,
+		ClientLogs:            a.cfg.ClientLogs,
+		CheckpointSyncEnabled: true,
+		EthereumPackage:       a.cfg.EthereumPackage,
+	}
+	config.SetDefaults()
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid job: %w", err)
+	}
+
+	service := synctest.NewService(log, config, Version)
+	if a.storage != nil {
+		service.EnableReportStorage(a.storage)
+	}
+
+	if err := service.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start sync test: %w", err)
+	}
+	defer func() {
+		if err := service.Stop(); err != nil {
+			log.WithError(err).Error("Failed to stop sync test service")
+		}
+	}()
+
+	return service.WaitForSync(ctx)
+}
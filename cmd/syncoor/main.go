@@ -27,6 +27,8 @@ const (
 	ExitCodeTimeout = 124
 	// ExitCodeContainerCrash indicates a container crashed
 	ExitCodeContainerCrash = 125
+	// ExitCodeRegression indicates a report comparison exceeded a regression threshold
+	ExitCodeRegression = 2
 )
 
 var rootCmd = &cobra.Command{
@@ -48,8 +50,14 @@ func init() {
 	// Add commands to root
 	rootCmd.AddCommand(NewSyncCommand())
 	rootCmd.AddCommand(NewServerCommand())
+	rootCmd.AddCommand(NewAgentCommand())
 	rootCmd.AddCommand(NewReportIndexCommand())
 	rootCmd.AddCommand(NewReportToMdCommand())
+	rootCmd.AddCommand(NewReportDiffCommand())
+	rootCmd.AddCommand(NewReportRegressCommand())
+	rootCmd.AddCommand(NewReportRenderCommand())
+	rootCmd.AddCommand(NewReportExportCommand())
+	rootCmd.AddCommand(NewRecoveryCommand())
 	rootCmd.AddCommand(newVersionCommand())
 	rootCmd.AddCommand(NewSysinfoCommand())
 }
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewRecoveryCommand() *cobra.Command {
+	var stateDir string
+
+	cmd := &cobra.Command{
+		Use:   "recovery",
+		Short: "Manage temporary recovery reports",
+		Long:  "List and clean temporary reports that sync runs keep for recovery after an interruption",
+	}
+
+	cmd.PersistentFlags().StringVar(&stateDir, "state-dir", report.DefaultStateDir, "Directory containing temporary recovery reports")
+
+	cmd.AddCommand(newRecoveryListCommand(&stateDir))
+	cmd.AddCommand(newRecoveryCleanCommand(&stateDir))
+
+	return cmd
+}
+
+func newRecoveryListCommand(stateDir *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List temporary recovery reports",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tempReports, err := listTempReports(cmd, *stateDir)
+			if err != nil {
+				return err
+			}
+
+			if len(tempReports) == 0 {
+				fmt.Printf("No temporary reports found in %s\n", *stateDir)
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NETWORK\tEL\tCL\tENCLAVE\tRUN ID\tAGE\tSIZE\tFILE")
+			for _, tempReport := range tempReports {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+					tempReport.Network,
+					tempReport.ELClient,
+					tempReport.CLClient,
+					valueOrDash(tempReport.Enclave),
+					valueOrDash(tempReport.RunID),
+					time.Since(tempReport.ModTime).Round(time.Second),
+					tempReport.Size,
+					tempReport.Path,
+				)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newRecoveryCleanCommand(stateDir *string) *cobra.Command {
+	var (
+		olderThan time.Duration
+		all       bool
+		dryRun    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove stale temporary recovery reports",
+		Long:  "Removes temporary recovery reports that have not been updated within --older-than, or all of them with --all",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := logrus.WithField("component", "recovery")
+
+			tempReports, err := listTempReports(cmd, *stateDir)
+			if err != nil {
+				return err
+			}
+
+			removed := 0
+			for _, tempReport := range tempReports {
+				if !all && time.Since(tempReport.ModTime) < olderThan {
+					continue
+				}
+
+				log := logger.WithFields(logrus.Fields{
+					"file":    tempReport.Path,
+					"enclave": tempReport.Enclave,
+					"run_id":  tempReport.RunID,
+					"age":     time.Since(tempReport.ModTime).Round(time.Second).String(),
+				})
+
+				if dryRun {
+					log.Info("Would remove temporary report")
+					removed++
+					continue
+				}
+
+				if err := os.Remove(tempReport.Path); err != nil {
+					log.WithError(err).Warn("Failed to remove temporary report")
+					continue
+				}
+				log.Info("Removed temporary report")
+				removed++
+			}
+
+			logger.WithFields(logrus.Fields{
+				"removed": removed,
+				"total":   len(tempReports),
+				"dry_run": dryRun,
+			}).Info("Temporary report cleanup complete")
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&olderThan, "older-than", 24*time.Hour, "Only remove temporary reports not updated within this duration")
+	cmd.Flags().BoolVar(&all, "all", false, "Remove all temporary reports regardless of age")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show which temporary reports would be removed without removing them")
+
+	return cmd
+}
+
+// listTempReports lists the temporary reports in stateDir, newest first
+func listTempReports(cmd *cobra.Command, stateDir string) ([]report.TempReportInfo, error) {
+	reportService := report.NewService(logrus.WithField("component", "recovery"))
+	reportService.SetStateDir(stateDir)
+
+	tempReports, err := reportService.ListTempReports(cmd.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list temporary reports: %w", err)
+	}
+	return tempReports, nil
+}
+
+// valueOrDash returns "-" for empty values in tabular output
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+// Report diff errors
+var (
+	ErrInvalidThreshold    = errors.New("invalid threshold")
+	ErrInvalidOutputFormat = errors.New("invalid output format")
+)
+
+// NewReportDiffCommand creates the report-diff command
+func NewReportDiffCommand() *cobra.Command {
+	var (
+		baseFile      string
+		headFile      string
+		format        string
+		outputFile    string
+		thresholds    []string
+		alignedPoints int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report-diff",
+		Short: "Compare two sync test reports",
+		Long: `Compares a head report against a base report (e.g. a new client release against the previous one)
+and emits deltas on duration, disk usage, memory, CPU, IO, time-to-milestones and block-aligned metrics.
+
+Exits with a non-zero code when any --threshold is exceeded.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			regressions, err := runReportDiff(baseFile, headFile, format, outputFile, thresholds, alignedPoints)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(ExitCodeError)
+			}
+			if len(regressions) > 0 {
+				fmt.Fprintf(os.Stderr, "Regression thresholds exceeded: %s\n", strings.Join(regressions, ", "))
+				os.Exit(ExitCodeRegression)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&baseFile, "base", "", "Base main report JSON file (required)")
+	cmd.Flags().StringVar(&headFile, "head", "", "Head main report JSON file (required)")
+	cmd.Flags().StringVar(&format, "format", "markdown", "Output format (markdown, json)")
+	cmd.Flags().StringVar(&outputFile, "output", "", "Output file (optional, defaults to stdout)")
+	cmd.Flags().StringSliceVar(&thresholds, "threshold", []string{},
+		"Maximum allowed increase in percent as metric=percent, e.g. duration=10 (can be used multiple times)")
+	cmd.Flags().IntVar(&alignedPoints, "aligned-points", 10, "Number of block heights to compare both runs at")
+	for _, flag := range []string{"base", "head"} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			panic(fmt.Sprintf("failed to mark %s flag as required: %v", flag, err))
+		}
+	}
+
+	return cmd
+}
+
+// runReportDiff compares the reports, writes the output and returns the regressed metrics
+func runReportDiff(baseFile, headFile, format, outputFile string, thresholds []string, alignedPoints int) ([]string, error) {
+	parsedThresholds, err := parseThresholds(thresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := report.LoadReport(baseFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base report: %w", err)
+	}
+	head, err := report.LoadReport(headFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load head report: %w", err)
+	}
+
+	diff, err := report.CompareReports(base, head, parsedThresholds, alignedPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	var output []byte
+	switch format {
+	case "markdown", "md":
+		output = []byte(generateDiffMarkdown(diff))
+	case "json":
+		if output, err = json.MarshalIndent(diff, "", "  "); err != nil {
+			return nil, fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		output = append(output, '\n')
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOutputFormat, format)
+	}
+
+	if outputFile == "" {
+		fmt.Print(string(output))
+	} else if err := os.WriteFile(outputFile, output, 0o644); err != nil { //nolint: gosec // Open read permissions are OK for the report
+		return nil, fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	return diff.Regressions, nil
+}
+
+// parseThresholds parses metric=percent threshold flags
+func parseThresholds(thresholds []string) (map[string]float64, error) {
+	parsed := make(map[string]float64, len(thresholds))
+	for _, threshold := range thresholds {
+		name, value, ok := strings.Cut(threshold, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q (expected metric=percent)", ErrInvalidThreshold, threshold)
+		}
+
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidThreshold, threshold, err)
+		}
+		parsed[strings.TrimSpace(name)] = percent
+	}
+	return parsed, nil
+}
+
+func generateDiffMarkdown(diff *report.ReportDiff) string {
+	var md strings.Builder
+
+	fmt.Fprintf(&md, "# Syncoor Report Diff: %s-%s-%s\n\n",
+		strings.ToLower(diff.Head.Network), strings.ToLower(diff.Head.ELClient), strings.ToLower(diff.Head.CLClient))
+
+	md.WriteString("| | Base | Head |\n")
+	md.WriteString("|-------|-------|-------|\n")
+	fmt.Fprintf(&md, "| **Run ID** | `%s` | `%s` |\n", diff.Base.RunID, diff.Head.RunID)
+	fmt.Fprintf(&md, "| **EL Client** | %s %s | %s %s |\n", diff.Base.ELClient, diff.Base.ELVersion, diff.Head.ELClient, diff.Head.ELVersion)
+	fmt.Fprintf(&md, "| **CL Client** | %s %s | %s %s |\n", diff.Base.CLClient, diff.Base.CLVersion, diff.Head.CLClient, diff.Head.CLVersion)
+	md.WriteString("\n")
+
+	addDiffMetrics(&md, diff)
+	addDiffMilestones(&md, diff)
+	addDiffBlockAligned(&md, diff)
+
+	return md.String()
+}
+
+func addDiffMetrics(md *strings.Builder, diff *report.ReportDiff) {
+	md.WriteString("## 📊 Metrics\n\n")
+	md.WriteString("| Metric | Base | Head | Delta | Threshold |\n")
+	md.WriteString("|-------|-------|-------|-------|-------|\n")
+	for _, metric := range diff.Metrics {
+		threshold := "-"
+		if metric.Threshold != nil {
+			icon := "✅"
+			if metric.Regression {
+				icon = "❌"
+			}
+			threshold = fmt.Sprintf("%s +%.1f%%", icon, *metric.Threshold)
+		}
+		delta := fmt.Sprintf("%+.1f%%", metric.DeltaPercent)
+		if metric.Base == 0 && metric.Head != 0 {
+			delta = "new" // No percent change from a zero baseline
+		}
+		fmt.Fprintf(md, "| **%s** | %s | %s | %s | %s |\n", metric.Label,
+			formatMetricValue(metric.Unit, metric.Base), formatMetricValue(metric.Unit, metric.Head),
+			delta, threshold)
+	}
+	md.WriteString("\n")
+}
+
+func addDiffMilestones(md *strings.Builder, diff *report.ReportDiff) {
+	if len(diff.Milestones) == 0 {
+		return
+	}
+
+	md.WriteString("## 🏁 Time to Milestones\n\n")
+	md.WriteString("| Milestone | Block | Base | Head | Delta |\n")
+	md.WriteString("|-------|-------|-------|-------|-------|\n")
+	for _, milestone := range diff.Milestones {
+		fmt.Fprintf(md, "| %d%% | %s | %s | %s | %s |\n", milestone.Percent, formatNumber(milestone.Block),
+			formatElapsed(milestone.BaseElapsed), formatElapsed(milestone.HeadElapsed),
+			formatElapsedDelta(milestone.BaseElapsed, milestone.HeadElapsed))
+	}
+	md.WriteString("\n")
+}
+
+func addDiffBlockAligned(md *strings.Builder, diff *report.ReportDiff) {
+	if len(diff.BlockAligned) == 0 {
+		return
+	}
+
+	md.WriteString("## 🧱 Block-Aligned Comparison\n\n")
+	md.WriteString("| Block | Elapsed (Base / Head) | EL Disk (Base / Head) | CL Disk (Base / Head) | EL Memory (Base / Head) |\n")
+	md.WriteString("|-------|-------|-------|-------|-------|\n")
+	for _, point := range diff.BlockAligned {
+		fmt.Fprintf(md, "| %s | %s / %s | %s / %s | %s / %s | %s / %s |\n", formatNumber(point.Block),
+			formatElapsed(point.BaseElapsed), formatElapsed(point.HeadElapsed),
+			formatBytes(point.BaseDiskEL), formatBytes(point.HeadDiskEL),
+			formatBytes(point.BaseDiskCL), formatBytes(point.HeadDiskCL),
+			formatBytes(point.BaseMemoryEL), formatBytes(point.HeadMemoryEL))
+	}
+	md.WriteString("\n")
+}
+
+// formatMetricValue formats a metric value according to its unit
+func formatMetricValue(unit string, value float64) string {
+	switch unit {
+	case "seconds":
+		return formatDuration(time.Duration(value) * time.Second)
+	case "bytes":
+		return formatBytes(uint64(value))
+	case "percent":
+		return fmt.Sprintf("%.1f%%", value)
+	default:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+}
+
+// formatElapsed formats seconds since sync start, or "-" if a milestone was not reached
+func formatElapsed(seconds int64) string {
+	if seconds < 0 {
+		return "-"
+	}
+	return formatDuration(time.Duration(seconds) * time.Second)
+}
+
+func formatElapsedDelta(base, head int64) string {
+	if base < 0 || head < 0 {
+		return "-"
+	}
+	if head < base {
+		return "-" + formatDuration(time.Duration(base-head)*time.Second)
+	}
+	return "+" + formatDuration(time.Duration(head-base)*time.Second)
+}
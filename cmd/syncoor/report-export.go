@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+// ErrExportInputRequired is returned when report-export has nothing to export
+var ErrExportInputRequired = errors.New("one of --input, --report-dir or --index is required")
+
+// NewReportExportCommand creates the report-export command
+func NewReportExportCommand() *cobra.Command {
+	var (
+		inputFiles []string
+		reportDir  string
+		indexFile  string
+		include    []string
+		exclude    []string
+		format     string
+		outputFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report-export",
+		Short: "Export sync test progress data for analysis tools",
+		Long: `Exports the progress entries of one or more runs, one row per entry:
+
+  csv          Comma-separated values with a header row
+  columnar     JSON object of column arrays with a schema, e.g. pandas.DataFrame(data["columns"])
+  openmetrics  Timestamped OpenMetrics samples for backfilling Prometheus
+               (promtool tsdb create-blocks-from openmetrics)`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := exportReports(inputFiles, reportDir, indexFile, include, exclude, format, outputFile); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(ExitCodeError)
+			}
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&inputFiles, "input", []string{}, "Main report JSON file to export (can be used multiple times)")
+	cmd.Flags().StringVar(&reportDir, "report-dir", "", "Export all reports below this directory")
+	cmd.Flags().StringVar(&indexFile, "index", "", "Export all reports listed in this index file or its shards")
+	cmd.Flags().StringSliceVar(&include, "include", []string{}, "Glob pattern of report files to export (can be used multiple times)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", []string{}, "Glob pattern of report files to skip (can be used multiple times)")
+	cmd.Flags().StringVar(&format, "format", "csv", "Output format (csv, columnar, openmetrics)")
+	cmd.Flags().StringVar(&outputFile, "output", "", "Output file (optional, defaults to stdout)")
+
+	return cmd
+}
+
+func exportReports(inputFiles []string, reportDir, indexFile string, include, exclude []string, format, outputFile string) error {
+	var export func(io.Writer, []*report.Result) error
+	switch format {
+	case "csv":
+		export = report.ExportCSV
+	case "columnar":
+		export = report.ExportColumnar
+	case "openmetrics":
+		export = report.ExportOpenMetrics
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidOutputFormat, format)
+	}
+
+	runs, err := loadExportRuns(inputFiles, reportDir, indexFile, include, exclude)
+	if err != nil {
+		return err
+	}
+
+	if outputFile == "" {
+		return writeExport(os.Stdout, export, runs)
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := writeExport(file, export, runs); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// loadExportRuns loads the explicitly given reports, or all reports of a directory or index
+func loadExportRuns(inputFiles []string, reportDir, indexFile string, include, exclude []string) ([]*report.Result, error) {
+	if len(inputFiles) == 0 {
+		if reportDir == "" && indexFile == "" {
+			return nil, ErrExportInputRequired
+		}
+		return loadHistory(reportDir, indexFile, include, exclude)
+	}
+
+	runs := make([]*report.Result, 0, len(inputFiles))
+	for _, inputFile := range inputFiles {
+		run, err := report.LoadReport(inputFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", inputFile, err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+func writeExport(w io.Writer, export func(io.Writer, []*report.Result) error, runs []*report.Result) error {
+	buffered := bufio.NewWriter(w)
+	if err := export(buffered, runs); err != nil {
+		return fmt.Errorf("failed to export reports: %w", err)
+	}
+	return buffered.Flush()
+}
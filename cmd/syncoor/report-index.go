@@ -2,11 +2,17 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/signal"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -16,17 +22,153 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// Report index errors
+var (
+	ErrCorruptedReports   = errors.New("corrupted reports found")
+	ErrWatchRequiresLocal = errors.New("watch mode is only supported with local storage")
+	ErrInvalidTimeBound   = errors.New("invalid time bound")
+	ErrMultipleReportDirs = errors.New("multiple report directories are only supported with local storage")
+	ErrReportDirOutside   = errors.New("report directory is not inside the index directory")
+)
+
+// indexTarget describes where reports are read from and where the index is written
+type indexTarget struct {
+	reportDirs []indexReportDir
+	source     report.Storage
+	output     report.Storage
+	outputKey  string
+	layout     *indexLayout
+}
+
+// indexReportDir is a local report directory and the key prefix its reports are indexed under
+type indexReportDir struct {
+	dir    string
+	prefix string
+}
+
+// indexLayout controls which reports are indexed and how the index is split into files
+type indexLayout struct {
+	network   string
+	since     string
+	labels    []string
+	retention string
+	shardBy   string
+	pageSize  int
+	trendRuns int
+}
+
+// register adds the index layout flags to a command
+func (l *indexLayout) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&l.network, "network", "", "Only index reports for this network")
+	cmd.Flags().StringVar(&l.since, "since", "", "Only index reports newer than a date (2006-01-02, RFC3339) or age (e.g. 72h, 14d)")
+	cmd.Flags().StringSliceVar(&l.labels, "label", []string{}, "Only index reports with this label, in key=value format (can be used multiple times)")
+	cmd.Flags().StringVar(&l.retention, "retention", "", "Drop reports older than this age from the index (e.g. 30d)")
+	cmd.Flags().StringVar(&l.shardBy, "shard-by", report.ShardByNone,
+		"Split the index into shard files listed in a manifest (none, network, client)")
+	cmd.Flags().IntVar(&l.pageSize, "page-size", 0, "Maximum entries per shard file, newest first (0 for unlimited)")
+	cmd.Flags().IntVar(&l.trendRuns, "trend-runs", report.DefaultTrendRuns, "Number of recent runs in the per client pair trend of the index aggregates")
+}
+
+// filter builds the entry filter, resolving relative time bounds against now
+func (l *indexLayout) filter(now time.Time) (report.IndexFilter, error) {
+	labels, err := parseLabels(l.labels)
+	if err != nil {
+		return report.IndexFilter{}, err
+	}
+
+	filter := report.IndexFilter{Network: l.network, Labels: labels}
+
+	if l.since != "" {
+		if filter.Since, err = parseTimeBound(l.since, now); err != nil {
+			return report.IndexFilter{}, err
+		}
+	}
+
+	if l.retention != "" {
+		age, err := parseAge(l.retention)
+		if err != nil {
+			return report.IndexFilter{}, err
+		}
+		if cutoff := now.Add(-age); cutoff.After(filter.Since) {
+			filter.Since = cutoff
+		}
+	}
+
+	return filter, nil
+}
+
+// validate checks the layout flags
+func (l *indexLayout) validate() error {
+	if _, err := l.filter(time.Now()); err != nil {
+		return err
+	}
+
+	switch l.shardBy {
+	case report.ShardByNone, report.ShardByNetwork, report.ShardByClient:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", report.ErrInvalidShardKey, l.shardBy)
+	}
+}
+
+// sharded reports whether the index is split into shard files
+func (l *indexLayout) sharded() bool {
+	return l.shardBy != "" && l.shardBy != report.ShardByNone
+}
+
+// parseTimeBound parses an absolute date or a relative age into a point in time
+func parseTimeBound(value string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	age, err := parseAge(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(-age), nil
+}
+
+// parseAge parses a Go duration, additionally accepting a number of days such as "30d"
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidTimeBound, value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimeBound, value)
+	}
+	return age, nil
+}
+
 func NewReportIndexCommand() *cobra.Command {
 	var (
-		reportDir  string
+		reportDirs []string
+		include    []string
+		exclude    []string
 		outputPath string
 		watch      bool
+		strict     bool
+		cacheFile  string
+		storage    storageFlags
+		layout     indexLayout
 	)
 
 	cmd := &cobra.Command{
 		Use:   "report-index",
 		Short: "Generate an index of sync test reports",
-		Long:  "Scans a directory for sync test reports and generates an index file containing metadata about each report",
+		Long: `Scans directory trees for sync test reports and generates an index file containing metadata about each report.
+
+With multiple report directories, report paths in the index are relative to the index file.
+
+In watch mode only reports whose files changed are re-read when the index is updated.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
@@ -36,55 +178,297 @@ func NewReportIndexCommand() *cobra.Command {
 			// Create index service
 			indexService := report.NewIndexService(logger)
 
-			// Determine output path
-			if outputPath == "" {
-				outputPath = filepath.Join(reportDir, "index.json")
+			target, err := newIndexTarget(&storage, reportDirs, outputPath)
+			if err != nil {
+				return err
+			}
+			if err := indexService.SetFilePatterns(include, exclude); err != nil {
+				return err
+			}
+			if err := layout.validate(); err != nil {
+				return err
+			}
+			target.layout = &layout
+			if watch && !storage.isLocal() {
+				return ErrWatchRequiresLocal
+			}
+
+			// Watch mode always caches entries in memory; --cache-file also persists them between runs
+			if watch || cacheFile != "" {
+				cache, err := report.NewIndexCache(cacheFile)
+				if err != nil {
+					return err
+				}
+				indexService.SetCache(cache)
 			}
 
 			// Generate initial index
-			if err := generateIndex(ctx, indexService, logger, reportDir, outputPath); err != nil {
+			index, err := generateIndex(ctx, indexService, logger, target, nil)
+			if err != nil {
 				return err
 			}
 
+			// In strict mode corrupted report pairs fail the command
+			if strict && len(index.Corrupted) > 0 {
+				return fmt.Errorf("%w: %d report(s) failed the integrity check", ErrCorruptedReports, len(index.Corrupted))
+			}
+
 			// If watch mode is disabled, exit after generating the index
 			if !watch {
 				return nil
 			}
 
 			// Watch mode: monitor for changes and regenerate index
-			return watchAndRegenerate(ctx, indexService, logger, reportDir, outputPath)
+			return watchAndRegenerate(ctx, indexService, logger, target)
 		},
 	}
 
 	// Add flags
-	cmd.Flags().StringVar(&reportDir, "report-dir", "./reports", "Directory containing sync test reports")
-	cmd.Flags().StringVar(&outputPath, "output", "", "Output path for the index file (defaults to {report-dir}/index.json)")
+	cmd.Flags().StringSliceVar(&reportDirs, "report-dir", []string{"./reports"}, "Directory containing sync test reports (can be used multiple times)")
+	cmd.Flags().StringSliceVar(&include, "include", []string{},
+		"Only index main report files matching this glob, e.g. '2025-*/**' (can be used multiple times)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", []string{}, "Skip main report files matching this glob (can be used multiple times)")
+	cmd.Flags().StringVar(&outputPath, "output", "",
+		"Output path for the index file, report directories must be inside its directory "+
+			"(defaults to index.json in the common parent of the report directories, or the index.json key for remote storage)")
 	cmd.Flags().BoolVar(&watch, "watch", false, "Watch for changes and automatically regenerate the index")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Exit with an error if any report pair fails the integrity check")
+	cmd.Flags().StringVar(&cacheFile, "cache-file", "", "Persist processed reports to this file so unchanged reports are not re-read on the next run")
+	storage.register(cmd)
+	layout.register(cmd)
 
 	return cmd
 }
 
-// generateIndex creates and saves an index of sync test reports
-func generateIndex(ctx context.Context, indexService report.IndexService, logger *logrus.Entry, reportDir, outputPath string) error {
+// newIndexTarget resolves the report source and index output. For local storage the
+// output is a file path; for remote storage it is a key in the same backend.
+func newIndexTarget(storage *storageFlags, reportDirs []string, outputPath string) (indexTarget, error) {
+	if len(reportDirs) == 0 {
+		reportDirs = []string{"./reports"}
+	}
+
+	if !storage.isLocal() {
+		if len(reportDirs) > 1 {
+			return indexTarget{}, ErrMultipleReportDirs
+		}
+		source, err := storage.build(reportDirs[0])
+		if err != nil {
+			return indexTarget{}, err
+		}
+		if outputPath == "" {
+			outputPath = "index.json"
+		}
+		return indexTarget{source: source, output: source, outputKey: outputPath}, nil
+	}
+
+	if outputPath == "" {
+		dir, err := commonDir(reportDirs)
+		if err != nil {
+			return indexTarget{}, err
+		}
+		outputPath = filepath.Join(dir, "index.json")
+	}
+
+	target := indexTarget{
+		output:    report.NewLocalStorage(filepath.Dir(outputPath)),
+		outputKey: filepath.Base(outputPath),
+	}
+
+	// A single directory keeps keys relative to itself; multiple directories are merged
+	// with keys relative to the index file so every entry can be resolved from it. They
+	// must be inside the index directory, keys can't leave the storage root.
+	if len(reportDirs) == 1 {
+		target.reportDirs = []indexReportDir{{dir: reportDirs[0]}}
+		target.source = report.NewLocalStorage(reportDirs[0])
+		return target, nil
+	}
+
+	mounts := make([]report.StorageMount, 0, len(reportDirs))
+	for _, dir := range reportDirs {
+		rel, err := relativeDir(filepath.Dir(outputPath), dir)
+		if err != nil {
+			return indexTarget{}, fmt.Errorf("failed to resolve report directory %s: %w", dir, err)
+		}
+		if !filepath.IsLocal(rel) && rel != "." {
+			return indexTarget{}, fmt.Errorf("%w: %s is outside %s", ErrReportDirOutside, dir, filepath.Dir(outputPath))
+		}
+
+		prefix := filepath.ToSlash(rel)
+		if prefix == "." {
+			prefix = ""
+		}
+		target.reportDirs = append(target.reportDirs, indexReportDir{dir: dir, prefix: prefix})
+		mounts = append(mounts, report.StorageMount{Prefix: prefix, Storage: report.NewLocalStorage(dir)})
+	}
+	target.source = report.NewMountedStorage(mounts...)
+
+	return target, nil
+}
+
+// relativeDir returns dir relative to base, resolving both to absolute paths first
+func relativeDir(base, dir string) (string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(absBase, absDir)
+}
+
+// commonDir returns the deepest directory containing all dirs
+func commonDir(dirs []string) (string, error) {
+	common, err := filepath.Abs(dirs[0])
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range dirs[1:] {
+		rel, err := relativeDir(common, dir)
+		if err != nil {
+			return "", err
+		}
+		for rel != "." && !filepath.IsLocal(rel) {
+			common = filepath.Dir(common)
+			if rel, err = relativeDir(common, dir); err != nil {
+				return "", err
+			}
+		}
+	}
+	return common, nil
+}
+
+// generateIndex creates and saves an index of sync test reports. If changedKeys is not
+// nil only those files are re-read; otherwise the whole source is scanned.
+func generateIndex(
+	ctx context.Context,
+	indexService report.IndexService,
+	logger *logrus.Entry,
+	target indexTarget,
+	changedKeys []string,
+) (*report.Index, error) {
 	// Generate index
-	logger.WithField("reportDir", reportDir).Info("Generating report index")
-	index, err := indexService.GenerateIndex(ctx, reportDir)
+	var (
+		index *report.Index
+		err   error
+	)
+	if changedKeys != nil {
+		index, err = indexService.UpdateIndex(ctx, target.source, changedKeys)
+	} else {
+		logger.WithField("source", target.source.String()).Info("Generating report index")
+		index, err = indexService.GenerateIndexFromStorage(ctx, target.source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate index: %w", err)
+	}
+
+	// Apply filters and retention, resolving relative bounds at generation time
+	filter, err := target.layout.filter(time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to generate index: %w", err)
+		return nil, err
 	}
+	index = report.FilterIndex(index, filter)
+	index.Aggregates = report.ComputeAggregates(index.Entries, target.layout.trendRuns)
 
 	// Save index
-	logger.WithField("outputPath", outputPath).Info("Saving index")
-	if err := indexService.SaveIndex(ctx, index, outputPath); err != nil {
-		return fmt.Errorf("failed to save index: %w", err)
+	logger.WithField("output", target.outputKey).Info("Saving index")
+	if err := saveIndex(ctx, indexService, logger, target, index); err != nil {
+		return nil, fmt.Errorf("failed to save index: %w", err)
+	}
+
+	for _, corrupted := range index.Corrupted {
+		logger.WithFields(logrus.Fields{
+			"mainFile":     corrupted.MainFile,
+			"progressFile": corrupted.ProgressFile,
+			"reason":       corrupted.Reason,
+		}).Warn("Corrupted report pair")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"entriesCount":   len(index.Entries),
+		"corruptedCount": len(index.Corrupted),
+	}).Info("Report index generated successfully")
+	return index, nil
+}
+
+// saveIndex writes the index, either as a single file or as shard files plus a manifest.
+// Shard files listed in the previous manifest that are no longer part of the index are removed.
+func saveIndex(ctx context.Context, indexService report.IndexService, logger *logrus.Entry, target indexTarget, index *report.Index) error {
+	// Shards live next to the manifest in a directory named after it, e.g. index/hoodi.json
+	outputDir := path.Dir(target.outputKey)
+	shardDir := strings.TrimSuffix(path.Base(target.outputKey), path.Ext(target.outputKey))
+
+	// Read before the manifest is replaced
+	previous := previousShardFiles(ctx, target.output, target.outputKey)
+
+	if !target.layout.sharded() {
+		if err := indexService.SaveIndexToStorage(ctx, index, target.output, target.outputKey); err != nil {
+			return err
+		}
+		removeStaleShards(ctx, logger, target.output, outputDir, shardDir, previous, nil)
+		return nil
+	}
+
+	manifest, shards, err := report.ShardIndex(index, target.layout.shardBy, shardDir, target.layout.pageSize)
+	if err != nil {
+		return err
 	}
 
-	logger.WithField("entriesCount", len(index.Entries)).Info("Report index generated successfully")
+	// Shards are written before the manifest so it never references missing files
+	for file, shard := range shards {
+		if err := indexService.SaveIndexToStorage(ctx, shard, target.output, path.Join(outputDir, file)); err != nil {
+			return err
+		}
+	}
+
+	if err := indexService.SaveIndexToStorage(ctx, manifest, target.output, target.outputKey); err != nil {
+		return err
+	}
+
+	logger.WithField("shards", len(manifest.Shards)).Info("Index shards saved")
+	removeStaleShards(ctx, logger, target.output, outputDir, shardDir, previous, shards)
 	return nil
 }
 
-// watchAndRegenerate monitors the reports directory for changes and regenerates the index
-func watchAndRegenerate(ctx context.Context, indexService report.IndexService, logger *logrus.Entry, reportDir, outputPath string) error {
+// previousShardFiles returns the shard files of the manifest at key, relative to it. Files
+// the command didn't list in a manifest are never removed.
+func previousShardFiles(ctx context.Context, storage report.Storage, key string) []string {
+	data, err := storage.Read(ctx, key)
+	if err != nil {
+		return nil
+	}
+
+	var manifest report.Index
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, shard := range manifest.Shards {
+		files = append(files, shard.Files...)
+	}
+	return files
+}
+
+// removeStaleShards deletes the previous shard files that are not part of the current index.
+// Only JSON files directly in shardDir are removed, whatever the previous manifest lists.
+func removeStaleShards(
+	ctx context.Context, logger *logrus.Entry, storage report.Storage, outputDir, shardDir string, previous []string, keep map[string]*report.Index,
+) {
+	for _, file := range previous {
+		if _, ok := keep[file]; ok || path.Dir(file) != shardDir || path.Ext(file) != ".json" {
+			continue
+		}
+		key := path.Join(outputDir, file)
+		if err := storage.Delete(ctx, key); err != nil && !errors.Is(err, report.ErrObjectNotFound) {
+			logger.WithField("file", key).WithError(err).Warn("Failed to remove stale shard file")
+		}
+	}
+}
+
+// watchAndRegenerate monitors the reports directory tree for changes and updates the index
+func watchAndRegenerate(ctx context.Context, indexService report.IndexService, logger *logrus.Entry, target indexTarget) error {
 	// Create file system watcher
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -96,100 +480,181 @@ func watchAndRegenerate(ctx context.Context, indexService report.IndexService, l
 		}
 	}()
 
-	// Add the reports directory to the watcher
-	if err := watcher.Add(reportDir); err != nil {
-		return fmt.Errorf("failed to watch directory %s: %w", reportDir, err)
+	// fsnotify is not recursive, so every directory below the report directories is watched
+	for _, reportDir := range target.reportDirs {
+		if err := watchTree(watcher, reportDir.dir); err != nil {
+			return fmt.Errorf("failed to watch directory %s: %w", reportDir.dir, err)
+		}
+		logger.WithField("reportDir", reportDir.dir).Info("Watching for changes in reports directory")
 	}
 
-	logger.WithField("reportDir", reportDir).Info("Watching for changes in reports directory")
-
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Debounce timer to avoid regenerating index too frequently
-	var debounceTimer *time.Timer
-	const debounceDelay = 2 * time.Second
+	// Changes are collected and applied together after a quiet period
+	pending := &pendingChanges{
+		indexService: indexService,
+		logger:       logger,
+		target:       target,
+		keys:         make(map[string]struct{}),
+	}
 
-	return watchLoop(ctx, watcher, sigChan, indexService, logger, reportDir, outputPath, &debounceTimer, debounceDelay)
+	return watchLoop(ctx, watcher, sigChan, pending)
+}
+
+// watchTree adds dir and all of its non-hidden subdirectories to the watcher
+func watchTree(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
 }
 
 // watchLoop handles the main event loop for file watching
-func watchLoop(
-	ctx context.Context,
-	watcher *fsnotify.Watcher,
-	sigChan <-chan os.Signal,
-	indexService report.IndexService,
-	logger *logrus.Entry,
-	reportDir, outputPath string,
-	debounceTimer **time.Timer,
-	debounceDelay time.Duration,
-) error {
+func watchLoop(ctx context.Context, watcher *fsnotify.Watcher, sigChan <-chan os.Signal, pending *pendingChanges) error {
 	for {
 		select {
 		case event, ok := <-watcher.Events:
 			if !ok {
 				return nil
 			}
-			handleFileEvent(event, indexService, logger, reportDir, outputPath, debounceTimer, debounceDelay, ctx)
+			handleFileEvent(ctx, watcher, event, pending)
 
 		case err, ok := <-watcher.Errors:
 			if !ok {
 				return nil
 			}
-			logger.WithError(err).Error("File watcher error")
+			pending.logger.WithError(err).Error("File watcher error")
 
 		case <-sigChan:
-			return handleShutdown(logger, debounceTimer, "Received shutdown signal, stopping watch mode")
+			pending.stop("Received shutdown signal, stopping watch mode")
+			return nil
 
 		case <-ctx.Done():
-			err := handleShutdown(logger, debounceTimer, "Context cancelled, stopping watch mode")
-			if err != nil {
-				return err
-			}
+			pending.stop("Context cancelled, stopping watch mode")
 			return fmt.Errorf("context cancelled: %w", ctx.Err())
 		}
 	}
 }
 
-// handleFileEvent processes file system events for .main.json files
-func handleFileEvent(
-	event fsnotify.Event,
-	indexService report.IndexService,
-	logger *logrus.Entry,
-	reportDir, outputPath string,
-	debounceTimer **time.Timer,
-	debounceDelay time.Duration,
-	ctx context.Context,
-) {
-	// Only process events for .main.json files
-	if !strings.HasSuffix(event.Name, ".main.json") {
+// keyForPath maps a file below one of the report directories to its index key.
+// Nested report directories take precedence over their parents.
+func (t indexTarget) keyForPath(file string) (string, bool) {
+	key, depth := "", -1
+	for _, reportDir := range t.reportDirs {
+		rel, err := filepath.Rel(reportDir.dir, file)
+		if err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+		if d := len(filepath.Clean(reportDir.dir)); d > depth {
+			key, depth = path.Join(reportDir.prefix, filepath.ToSlash(rel)), d
+		}
+	}
+	return key, depth >= 0
+}
+
+// handleFileEvent records changes to report files and new or removed directories
+func handleFileEvent(ctx context.Context, watcher *fsnotify.Watcher, event fsnotify.Event, pending *pendingChanges) {
+	key, ok := pending.target.keyForPath(event.Name)
+	if !ok || strings.HasPrefix(path.Base(key), ".") {
 		return
 	}
 
 	// Log the event for debugging
-	logger.WithFields(logrus.Fields{
+	pending.logger.WithFields(logrus.Fields{
 		"file": event.Name,
 		"op":   event.Op.String(),
 	}).Debug("File system event detected")
 
-	// Reset or create debounce timer
-	if *debounceTimer != nil {
-		(*debounceTimer).Stop()
-	}
-	*debounceTimer = time.AfterFunc(debounceDelay, func() {
-		logger.Info("Changes detected, regenerating index...")
-		if err := generateIndex(ctx, indexService, logger, reportDir, outputPath); err != nil {
-			logger.WithError(err).Error("Failed to regenerate index")
+	switch {
+	case strings.HasSuffix(key, ".main.json") || strings.HasSuffix(key, ".progress.json"):
+		pending.add(ctx, key, false)
+	case strings.HasSuffix(key, ".json"):
+		// Index and downsampled progress files never affect index entries
+	case event.Has(fsnotify.Create):
+		// A new directory may have been moved in with reports already inside
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			if err := watchTree(watcher, event.Name); err != nil {
+				pending.logger.WithField("dir", event.Name).WithError(err).Warn("Failed to watch directory")
+			}
+			pending.add(ctx, "", true)
 		}
-	})
+	case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+		// A removed directory takes its reports with it
+		pending.add(ctx, "", true)
+	}
+}
+
+// pendingChanges collects changed report keys and applies them after a debounce delay
+type pendingChanges struct {
+	indexService report.IndexService
+	logger       *logrus.Entry
+	target       indexTarget
+
+	mu     sync.Mutex
+	keys   map[string]struct{}
+	rescan bool
+	timer  *time.Timer
 }
 
-// handleShutdown handles graceful shutdown by stopping debounce timer
-func handleShutdown(logger *logrus.Entry, debounceTimer **time.Timer, message string) error {
-	logger.Info(message)
-	if *debounceTimer != nil {
-		(*debounceTimer).Stop()
+// debounceDelay avoids regenerating the index too frequently
+const debounceDelay = 2 * time.Second
+
+// add records a changed key, or a full rescan, and resets the debounce timer
+func (p *pendingChanges) add(ctx context.Context, key string, rescan bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key != "" {
+		p.keys[key] = struct{}{}
+	}
+	p.rescan = p.rescan || rescan
+
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.timer = time.AfterFunc(debounceDelay, func() { p.flush(ctx) })
+}
+
+// flush updates the index with the collected changes
+func (p *pendingChanges) flush(ctx context.Context) {
+	p.mu.Lock()
+	keys := make([]string, 0, len(p.keys))
+	for key := range p.keys {
+		keys = append(keys, key)
+	}
+	rescan := p.rescan
+	p.keys = make(map[string]struct{})
+	p.rescan = false
+	p.mu.Unlock()
+
+	if rescan {
+		keys = nil
+	}
+
+	p.logger.WithField("changedFiles", len(keys)).Info("Changes detected, updating index...")
+	if _, err := generateIndex(ctx, p.indexService, p.logger, p.target, keys); err != nil {
+		p.logger.WithError(err).Error("Failed to regenerate index")
+	}
+}
+
+// stop cancels a pending update
+func (p *pendingChanges) stop(message string) {
+	p.logger.Info(message)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.timer != nil {
+		p.timer.Stop()
 	}
-	return nil
 }
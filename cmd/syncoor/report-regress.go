@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// Report regress errors
+var (
+	ErrInvalidLabel         = errors.New("invalid label")
+	ErrHistoryRequired      = errors.New("either --report-dir or --index is required")
+	ErrInsufficientBaseline = errors.New("not enough baseline runs")
+)
+
+// reportRegressOptions holds the report-regress flags
+type reportRegressOptions struct {
+	reportFile    string
+	reportDir     string
+	indexFile     string
+	include       []string
+	exclude       []string
+	format        string
+	outputFile    string
+	labels        []string
+	matchLabels   bool
+	metrics       []string
+	minSamples    int
+	iqrMultiplier float64
+	minChange     float64
+	strict        bool
+}
+
+// NewReportRegressCommand creates the report-regress command
+func NewReportRegressCommand() *cobra.Command {
+	opts := &reportRegressOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "report-regress",
+		Short: "Check a sync test report for regressions against historical runs",
+		Long: `Finds prior successful runs with the same network and EL/CL clients (and optionally labels),
+builds a baseline (median and interquartile range) per metric and flags values above Q3 + k*IQR.
+
+Exits with a non-zero code when a regression is detected.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			regressions, err := runReportRegress(opts)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(ExitCodeError)
+			}
+			if len(regressions) > 0 {
+				fmt.Fprintf(os.Stderr, "Regressions detected: %s\n", strings.Join(regressions, ", "))
+				os.Exit(ExitCodeRegression)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.reportFile, "report", "", "Main report JSON file to check (required)")
+	cmd.Flags().StringVar(&opts.reportDir, "report-dir", "", "Directory containing historical reports")
+	cmd.Flags().StringVar(&opts.indexFile, "index", "", "Index file listing historical reports (alternative to --report-dir)")
+	cmd.Flags().StringSliceVar(&opts.include, "include", []string{}, "Glob pattern of historical report files to use (can be used multiple times)")
+	cmd.Flags().StringSliceVar(&opts.exclude, "exclude", []string{}, "Glob pattern of historical report files to skip (can be used multiple times)")
+	cmd.Flags().StringVar(&opts.format, "format", "markdown", "Output format (markdown, json)")
+	cmd.Flags().StringVar(&opts.outputFile, "output", "", "Output file (optional, defaults to stdout)")
+	cmd.Flags().StringSliceVar(&opts.labels, "label", []string{}, "Only use baseline runs with this label, in key=value format (can be used multiple times)")
+	cmd.Flags().BoolVar(&opts.matchLabels, "match-labels", false, "Only use baseline runs carrying all labels of the checked report")
+	cmd.Flags().StringSliceVar(&opts.metrics, "metric", report.DefaultRegressionMetrics, "Metrics to check (can be used multiple times)")
+	cmd.Flags().IntVar(&opts.minSamples, "min-samples", 3, "Minimum number of baseline runs required to flag regressions")
+	cmd.Flags().Float64Var(&opts.iqrMultiplier, "iqr-multiplier", 1.5, "Values above Q3 + multiplier*IQR are flagged")
+	cmd.Flags().Float64Var(&opts.minChange, "min-change", 5, "Minimum increase over the baseline median in percent to flag a regression")
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "Fail when there are fewer than --min-samples baseline runs")
+	if err := cmd.MarkFlagRequired("report"); err != nil {
+		panic(fmt.Sprintf("failed to mark report flag as required: %v", err))
+	}
+
+	return cmd
+}
+
+// runReportRegress checks the report, writes the output and returns the regressed metrics
+func runReportRegress(opts *reportRegressOptions) ([]string, error) {
+	labels, err := parseLabels(opts.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := report.LoadReport(opts.reportFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load report: %w", err)
+	}
+	if opts.matchLabels {
+		for key, value := range candidate.Labels {
+			labels[key] = value
+		}
+	}
+
+	history, err := loadHistory(opts.reportDir, opts.indexFile, opts.include, opts.exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := report.DetectRegressions(candidate, history, report.RegressionOptions{
+		Metrics:          opts.metrics,
+		Labels:           labels,
+		MinSamples:       opts.minSamples,
+		IQRMultiplier:    opts.iqrMultiplier,
+		MinChangePercent: opts.minChange,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var output []byte
+	switch opts.format {
+	case "markdown", "md":
+		output = []byte(generateRegressMarkdown(result, opts))
+	case "json":
+		if output, err = json.MarshalIndent(result, "", "  "); err != nil {
+			return nil, fmt.Errorf("failed to marshal regression report: %w", err)
+		}
+		output = append(output, '\n')
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOutputFormat, opts.format)
+	}
+
+	if opts.outputFile == "" {
+		fmt.Print(string(output))
+	} else if err := os.WriteFile(opts.outputFile, output, 0o644); err != nil { //nolint: gosec // Open read permissions are OK for the report
+		return nil, fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	if len(result.BaselineRuns) == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: no baseline runs match %s/%s/%s in %d historical reports, nothing was checked\n",
+			result.Report.Network, result.Report.ELClient, result.Report.CLClient, len(history))
+	}
+
+	if opts.strict && result.InsufficientData {
+		return nil, fmt.Errorf("%w: found %d, need %d", ErrInsufficientBaseline, len(result.BaselineRuns), opts.minSamples)
+	}
+
+	return result.Regressions, nil
+}
+
+// loadHistory loads the historical reports below a report directory or listed in an index,
+// including the shard files of a sharded index. Reports that fail to load are skipped with a warning.
+func loadHistory(reportDir, indexFile string, include, exclude []string) ([]*report.Result, error) {
+	logger := logrus.WithField("component", "report-regress")
+
+	if reportDir == "" && indexFile == "" {
+		return nil, ErrHistoryRequired
+	}
+
+	mainFiles, err := report.HistoryFiles(context.Background(), reportDir, indexFile, include, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*report.Result, 0, len(mainFiles))
+	for _, mainFile := range mainFiles {
+		result, err := report.LoadReport(mainFile)
+		if err != nil {
+			logger.WithField("file", mainFile).WithError(err).Warn("Skipping report that failed to load")
+			continue
+		}
+		history = append(history, result)
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("%w: none of the %d reports could be loaded", report.ErrNoHistory, len(mainFiles))
+	}
+
+	return history, nil
+}
+
+// parseLabels parses key=value label flags
+func parseLabels(labels []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(labels))
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q (expected key=value)", ErrInvalidLabel, label)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+func generateRegressMarkdown(result *report.RegressionReport, opts *reportRegressOptions) string {
+	var md strings.Builder
+
+	fmt.Fprintf(&md, "# Syncoor Regression Check: %s-%s-%s\n\n",
+		strings.ToLower(result.Report.Network), strings.ToLower(result.Report.ELClient), strings.ToLower(result.Report.CLClient))
+
+	md.WriteString("| Field | Value |\n")
+	md.WriteString("|-------|-------|\n")
+	fmt.Fprintf(&md, "| **Run ID** | `%s` |\n", result.Report.RunID)
+	fmt.Fprintf(&md, "| **EL Client** | %s %s |\n", result.Report.ELClient, result.Report.ELVersion)
+	fmt.Fprintf(&md, "| **CL Client** | %s %s |\n", result.Report.CLClient, result.Report.CLVersion)
+	fmt.Fprintf(&md, "| **Baseline Runs** | %d |\n", len(result.BaselineRuns))
+	md.WriteString("\n")
+
+	if result.InsufficientData {
+		fmt.Fprintf(&md, "> ⚠️ Not enough baseline runs to detect regressions (found %d, need %d)\n\n",
+			len(result.BaselineRuns), opts.minSamples)
+	}
+
+	md.WriteString("## 📈 Baseline Comparison\n\n")
+	md.WriteString("| Metric | Value | Median | IQR | Upper Fence | Change | Result |\n")
+	md.WriteString("|-------|-------|-------|-------|-------|-------|-------|\n")
+	for _, check := range result.Checks {
+		status := "✅"
+		if check.Regression {
+			status = "❌ Regression"
+		} else if result.InsufficientData {
+			status = "-"
+		}
+		fmt.Fprintf(&md, "| **%s** | %s | %s | %s | %s | %+.1f%% | %s |\n", check.Label,
+			formatMetricValue(check.Unit, check.Value),
+			formatMetricValue(check.Unit, check.Baseline.Median),
+			formatMetricValue(check.Unit, check.Baseline.IQR),
+			formatMetricValue(check.Unit, check.UpperFence),
+			check.ChangePercent, status)
+	}
+	md.WriteString("\n")
+
+	return md.String()
+}
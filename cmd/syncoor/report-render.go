@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+// Rendered chart size in pixels
+const (
+	renderChartWidth  = 720
+	renderChartHeight = 240
+)
+
+// NewReportRenderCommand creates the report-render command
+func NewReportRenderCommand() *cobra.Command {
+	var (
+		inputFile  string
+		outputFile string
+		format     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report-render",
+		Short: "Render a sync test report as a self-contained HTML page",
+		Long: `Renders a main report and its progress file as a single HTML page with embedded SVG charts
+of block/slot, disk, memory, CPU and peers. The page has no external dependencies and can be
+attached to CI artifacts or emails.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := renderReport(inputFile, outputFile, format); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(ExitCodeError)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&inputFile, "input", "", "Main report JSON file (required)")
+	cmd.Flags().StringVar(&outputFile, "output", "", "Output file (optional, defaults to input file with .html extension)")
+	cmd.Flags().StringVar(&format, "format", "html", "Output format (html)")
+	if err := cmd.MarkFlagRequired("input"); err != nil {
+		panic(fmt.Sprintf("failed to mark input flag as required: %v", err))
+	}
+
+	return cmd
+}
+
+func renderReport(inputFile, outputFile, format string) error {
+	if format != "html" {
+		return fmt.Errorf("%w: %s", ErrInvalidOutputFormat, format)
+	}
+
+	result, err := report.LoadReport(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to load report: %w", err)
+	}
+
+	page, err := generateReportHTML(result)
+	if err != nil {
+		return err
+	}
+
+	if outputFile == "" {
+		outputFile = strings.TrimSuffix(inputFile, ".main.json")
+		outputFile = strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + ".html"
+	}
+
+	if err := os.WriteFile(outputFile, page, 0o644); err != nil { //nolint: gosec // Open read permissions are OK for the report
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	fmt.Printf("✅ Successfully rendered %s to %s\n", inputFile, outputFile)
+	return nil
+}
+
+// renderRow is a label/value row in a rendered table
+type renderRow struct {
+	Label string
+	Value string
+}
+
+// renderMilestone is a milestone row in the rendered report
+type renderMilestone struct {
+	Percent int
+	Block   string
+	Elapsed string
+}
+
+// renderPage holds the data of the HTML report template
+type renderPage struct {
+	Title      string
+	Status     string
+	StatusText string
+	Summary    []renderRow
+	Clients    [][3]string
+	Resources  []renderRow
+	Milestones []renderMilestone
+	Charts     []template.HTML
+	System     []renderRow
+	Labels     []renderRow
+	Generated  string
+}
+
+func generateReportHTML(result *report.Result) ([]byte, error) {
+	metrics := report.ComputeMetrics(result)
+	status := result.SyncStatus
+
+	page := renderPage{
+		Title: fmt.Sprintf("Syncoor Test Report: %s-%s-%s", strings.ToLower(result.Network),
+			strings.ToLower(result.ExecutionClientInfo.Type), strings.ToLower(result.ConsensusClientInfo.Type)),
+		Status:     status.Status,
+		StatusText: status.StatusMessage,
+		Summary: []renderRow{
+			{"Run ID", result.RunID},
+			{"Network", result.Network},
+			{"Start Time", time.Unix(status.Start, 0).UTC().Format("2006-01-02 15:04:05 UTC")},
+			{"End Time", time.Unix(status.End, 0).UTC().Format("2006-01-02 15:04:05 UTC")},
+			{"Duration", formatDuration(time.Duration(metrics.Duration) * time.Second)},
+			{"Final Block", formatNumber(status.Block)},
+			{"Final Slot", formatNumber(status.Slot)},
+			{"Progress Entries", fmt.Sprintf("%d", len(status.SyncProgress))},
+		},
+		Clients: [][3]string{
+			{"Client", result.ExecutionClientInfo.Type, result.ConsensusClientInfo.Type},
+			{"Version", result.ExecutionClientInfo.Version, result.ConsensusClientInfo.Version},
+			{"Image", result.ExecutionClientInfo.Image, result.ConsensusClientInfo.Image},
+		},
+		Generated: time.Now().UTC().Format("2006-01-02 15:04:05 UTC"),
+	}
+
+	for _, definition := range report.RunMetricDefinitions {
+		if definition.Name == "duration" {
+			continue
+		}
+		page.Resources = append(page.Resources, renderRow{definition.Label, formatMetricValue(definition.Unit, definition.Value(metrics))})
+	}
+
+	for _, milestone := range report.ComputeMilestones(result, status.Block, report.DefaultMilestonePercents) {
+		page.Milestones = append(page.Milestones, renderMilestone{
+			Percent: milestone.Percent,
+			Block:   formatNumber(milestone.Block),
+			Elapsed: formatElapsed(milestone.Elapsed),
+		})
+	}
+
+	for _, chart := range report.ProgressCharts(result, report.DefaultChartPoints) {
+		svg := chart.SVG(renderChartWidth, renderChartHeight)
+		page.Charts = append(page.Charts, template.HTML(svg)) //nolint:gosec // SVG is generated and escaped by us
+	}
+
+	if info := result.SystemInfo; info != nil {
+		page.System = []renderRow{
+			{"Hostname", info.Hostname},
+			{"Operating System", strings.TrimSpace(info.OSName + " " + info.OSVersion + " " + info.OSArchitecture)},
+			{"CPU", fmt.Sprintf("%s (%d cores)", info.CPUModel, info.CPUCores)},
+			{"Memory", formatBytes(info.TotalMemory)},
+			{"Syncoor Version", info.SyncoorVersion},
+		}
+	}
+
+	for key, value := range result.Labels {
+		page.Labels = append(page.Labels, renderRow{key, value})
+	}
+	sort.Slice(page.Labels, func(i, j int) bool { return page.Labels[i].Label < page.Labels[j].Label })
+
+	var out bytes.Buffer
+	if err := reportHTMLTemplate.Execute(&out, page); err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #111827; max-width: 760px; margin: 24px auto; padding: 0 16px; }
+h1 { font-size: 22px; } h2 { font-size: 17px; margin-top: 28px; border-bottom: 1px solid #e5e7eb; padding-bottom: 4px; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { text-align: left; padding: 4px 8px; border-bottom: 1px solid #f3f4f6; }
+th { color: #6b7280; font-weight: 600; }
+code { font-size: 12px; }
+.status { display: inline-block; padding: 2px 10px; border-radius: 10px; font-weight: 600; background: #fee2e2; color: #991b1b; }
+.status.success { background: #dcfce7; color: #166534; } .status.timeout { background: #fef3c7; color: #92400e; }
+.chart { margin: 12px 0; } .chart svg { max-width: 100%; height: auto; }
+footer { margin-top: 32px; font-size: 11px; color: #9ca3af; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p><span class="status {{.Status}}">{{if .Status}}{{.Status}}{{else}}unknown{{end}}</span> {{.StatusText}}</p>
+
+<h2>Summary</h2>
+<table>{{range .Summary}}<tr><th>{{.Label}}</th><td>{{.Value}}</td></tr>{{end}}</table>
+
+<h2>Clients</h2>
+<table><tr><th></th><th>Execution Layer</th><th>Consensus Layer</th></tr>
+{{range .Clients}}<tr><th>{{index . 0}}</th><td><code>{{index . 1}}</code></td><td><code>{{index . 2}}</code></td></tr>{{end}}</table>
+
+{{if .Milestones}}<h2>Milestones</h2>
+<table><tr><th>Progress</th><th>Block</th><th>Time</th></tr>
+{{range .Milestones}}<tr><td>{{.Percent}}%</td><td>{{.Block}}</td><td>{{.Elapsed}}</td></tr>{{end}}</table>{{end}}
+
+<h2>Resource Usage</h2>
+<table>{{range .Resources}}<tr><th>{{.Label}}</th><td>{{.Value}}</td></tr>{{end}}</table>
+
+{{if .Charts}}<h2>Charts</h2>
+{{range .Charts}}<div class="chart">{{.}}</div>
+{{end}}{{end}}
+
+{{if .System}}<h2>System</h2>
+<table>{{range .System}}<tr><th>{{.Label}}</th><td>{{.Value}}</td></tr>{{end}}</table>{{end}}
+
+{{if .Labels}}<h2>Labels</h2>
+<table>{{range .Labels}}<tr><th>{{.Label}}</th><td>{{.Value}}</td></tr>{{end}}</table>{{end}}
+
+<footer>Generated by syncoor on {{.Generated}}</footer>
+</body>
+</html>
+`))
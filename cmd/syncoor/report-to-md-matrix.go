@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+)
+
+// ErrNoReports is returned when the report-to-md inputs contain no main report files
+var ErrNoReports = errors.New("no main report files found")
+
+// matrixRun is a loaded report in a multi-report summary
+type matrixRun struct {
+	file     string
+	report   *MainReport
+	progress *report.Result // Nil without progress analysis
+}
+
+// isMultiReportInput reports whether the inputs need a matrix summary
+func isMultiReportInput(inputs []string) bool {
+	if len(inputs) != 1 {
+		return true
+	}
+	info, err := os.Stat(inputs[0])
+	return err == nil && info.IsDir()
+}
+
+// convertReportsToMatrixMarkdown writes a single summary of several reports
+func convertReportsToMatrixMarkdown(inputs []string, outputFile string) error {
+	files, err := collectMainReportFiles(inputs)
+	if err != nil {
+		return err
+	}
+
+	runs := make([]matrixRun, 0, len(files))
+	for _, file := range files {
+		report, progress, err := loadReport(file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		runs = append(runs, matrixRun{file: file, report: report, progress: progress})
+	}
+
+	markdown := generateMatrixMarkdown(runs)
+
+	if outputFile == "" {
+		fmt.Print(markdown)
+		return nil
+	}
+
+	if err := os.WriteFile(outputFile, []byte(markdown), 0o644); err != nil { //nolint: gosec // Open read permissions are OK for the report
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	fmt.Printf("✅ Successfully summarized %d reports to %s\n", len(runs), outputFile)
+	return nil
+}
+
+// collectMainReportFiles expands directories into the main report files they contain
+func collectMainReportFiles(inputs []string) ([]string, error) {
+	files := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		cleanInput := filepath.Clean(input)
+		if strings.Contains(cleanInput, "..") {
+			return nil, ErrInvalidFilePath
+		}
+
+		err := filepath.WalkDir(cleanInput, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// Explicit files are always used, directories contribute their main report files
+			if !d.IsDir() && (path == cleanInput || strings.HasSuffix(path, ".main.json")) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", input, err)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, ErrNoReports
+	}
+	return files, nil
+}
+
+func generateMatrixMarkdown(runs []matrixRun) string {
+	var md strings.Builder
+
+	// Newest runs first, so the matrix shows the latest run of each pair
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].report.Timestamp > runs[j].report.Timestamp })
+
+	networks := make(map[string][]matrixRun)
+	for _, run := range runs {
+		network := strings.ToLower(run.report.Network)
+		networks[network] = append(networks[network], run)
+	}
+
+	md.WriteString("# Syncoor Test Summary\n\n")
+	for _, network := range sortedKeys(networks) {
+		addMatrixTable(&md, network, networks[network])
+	}
+
+	md.WriteString("## 📋 Runs\n\n")
+	for _, run := range runs {
+		addRunDetails(&md, run)
+	}
+
+	return md.String()
+}
+
+// addMatrixTable renders an EL x CL table with the latest run of each pair
+func addMatrixTable(md *strings.Builder, network string, runs []matrixRun) {
+	cells := make(map[[2]string]*MainReport)
+	elClients := make(map[string]struct{})
+	clClients := make(map[string]struct{})
+	for _, run := range runs {
+		el := strings.ToLower(run.report.ExecutionClientInfo.Type)
+		cl := strings.ToLower(run.report.ConsensusClientInfo.Type)
+		if _, ok := cells[[2]string{el, cl}]; !ok {
+			cells[[2]string{el, cl}] = run.report
+		}
+		elClients[el] = struct{}{}
+		clClients[cl] = struct{}{}
+	}
+	cls := sortedKeys(clClients)
+
+	fmt.Fprintf(md, "## 🌐 %s\n\n", network)
+	md.WriteString("| EL \\ CL | " + strings.Join(cls, " | ") + " |\n")
+	md.WriteString("|-------|" + strings.Repeat("-------|", len(cls)) + "\n")
+	for _, el := range sortedKeys(elClients) {
+		md.WriteString("| **" + el + "** |")
+		for _, cl := range cls {
+			md.WriteString(" " + matrixCell(cells[[2]string{el, cl}]) + " |")
+		}
+		md.WriteString("\n")
+	}
+	md.WriteString("\n")
+}
+
+// matrixCell summarizes a run as status, duration and EL disk usage
+func matrixCell(report *MainReport) string {
+	if report == nil {
+		return "-"
+	}
+
+	// Reports of runs in progress have no end time yet
+	duration := "-"
+	switch status := report.SyncStatus; {
+	case status.End == 0:
+		duration = "running"
+	case status.End >= status.Start:
+		duration = formatDuration(time.Duration(status.End-status.Start) * time.Second)
+	}
+
+	cell := statusEmoji(report.SyncStatus.Status) + " " + duration
+	if report.SyncStatus.LastEntry != nil {
+		cell += "<br>💾 " + formatBytes(report.SyncStatus.LastEntry.DE)
+	}
+	return cell
+}
+
+// addRunDetails renders the full summary of a run in a collapsible section
+func addRunDetails(md *strings.Builder, run matrixRun) {
+	report := run.report
+	fmt.Fprintf(md, "<details>\n<summary>%s %s-%s-%s (%s)</summary>\n\n", statusEmoji(report.SyncStatus.Status),
+		strings.ToLower(report.Network), strings.ToLower(report.ExecutionClientInfo.Type),
+		strings.ToLower(report.ConsensusClientInfo.Type), report.RunID)
+	md.WriteString(demoteHeadings(generateMarkdownSummary(report, run.file, run.progress), 2))
+	md.WriteString("\n</details>\n\n")
+}
+
+// demoteHeadings lowers markdown headings outside code blocks by the given number of levels
+func demoteHeadings(markdown string, levels int) string {
+	lines := strings.Split(markdown, "\n")
+	inCode := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if !inCode && strings.HasPrefix(line, "#") {
+			lines[i] = strings.Repeat("#", levels) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ethpandaops/syncoor/pkg/report"
 	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -21,23 +23,33 @@ var ErrInvalidFilePath = errors.New("invalid input file path")
 // ReportToMdCommand creates the report-to-md command
 func NewReportToMdCommand() *cobra.Command {
 	var (
-		inputFile  string
+		inputFiles []string
 		outputFile string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "report-to-md",
 		Short: "Convert a JSON report to markdown summary",
-		Long:  "Converts a syncoor main report JSON file to a human-readable markdown summary",
+		Long: `Converts a syncoor main report JSON file to a human-readable markdown summary.
+
+Given several inputs or a directory, produces a single summary with an EL x CL matrix per
+network and collapsible details per run, written to stdout unless --output is set.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := convertReportToMarkdown(inputFile, outputFile); err != nil {
+			var err error
+			if isMultiReportInput(inputFiles) {
+				err = convertReportsToMatrixMarkdown(inputFiles, outputFile)
+			} else {
+				err = convertReportToMarkdown(inputFiles[0], outputFile)
+			}
+			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
 		},
 	}
 
-	cmd.Flags().StringVar(&inputFile, "input", "", "Input JSON report file (required)")
+	cmd.Flags().StringSliceVar(&inputFiles, "input", []string{},
+		"Input JSON report file or directory of reports (required, can be used multiple times)")
 	cmd.Flags().StringVar(&outputFile, "output", "", "Output markdown file (optional, defaults to input file with .md extension)")
 	if err := cmd.MarkFlagRequired("input"); err != nil {
 		panic(fmt.Sprintf("failed to mark input flag as required: %v", err))
@@ -99,16 +111,9 @@ func convertReportToMarkdown(inputFile, outputFile string) error {
 		return ErrInvalidFilePath
 	}
 
-	// Read the input JSON file
-	data, err := os.ReadFile(cleanInput)
+	report, progress, err := loadReport(cleanInput)
 	if err != nil {
-		return fmt.Errorf("failed to read input file: %w", err)
-	}
-
-	// Parse the JSON
-	var report MainReport
-	if err := json.Unmarshal(data, &report); err != nil {
-		return fmt.Errorf("failed to parse JSON: %w", err)
+		return err
 	}
 
 	// Determine output file path
@@ -118,7 +123,7 @@ func convertReportToMarkdown(inputFile, outputFile string) error {
 	}
 
 	// Generate markdown content
-	markdown := generateMarkdownSummary(&report, cleanInput)
+	markdown := generateMarkdownSummary(report, cleanInput, progress)
 
 	// Write the markdown file
 	if err := os.WriteFile(outputFile, []byte(markdown), 0o644); err != nil { //nolint: gosec // Open read permissions are OK for the report
@@ -129,7 +134,49 @@ func convertReportToMarkdown(inputFile, outputFile string) error {
 	return nil
 }
 
-func generateMarkdownSummary(report *MainReport, inputFile string) string {
+// loadReport reads a main report file, along with its progress file for charts and
+// milestones. The progress is nil if the progress file is missing or fails verification.
+func loadReport(inputFile string) (*MainReport, *report.Result, error) {
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var result report.Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	mainReport, err := newMainReport(&result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := report.LoadProgress(inputFile, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ Skipping progress analysis for %s: %v\n", inputFile, err)
+		return mainReport, nil, nil
+	}
+	if len(result.SyncStatus.SyncProgress) == 0 {
+		return mainReport, nil, nil
+	}
+	return mainReport, &result, nil
+}
+
+// newMainReport converts a report to the fields the summary renders
+func newMainReport(result *report.Result) (*MainReport, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	var mainReport MainReport
+	if err := json.Unmarshal(data, &mainReport); err != nil {
+		return nil, fmt.Errorf("failed to decode report: %w", err)
+	}
+	return &mainReport, nil
+}
+
+func generateMarkdownSummary(report *MainReport, inputFile string, progress *report.Result) string {
 	var md strings.Builder
 	titleCaser := cases.Title(language.English)
 
@@ -154,6 +201,11 @@ func generateMarkdownSummary(report *MainReport, inputFile string) string {
 	// Sync Results
 	addSyncResults(&md, report)
 
+	// Progress analysis from the progress file
+	if progress != nil {
+		addProgressAnalysis(&md, progress)
+	}
+
 	// System Information
 	if report.SystemInfo != nil {
 		addSystemInfo(&md, report.SystemInfo)
@@ -180,13 +232,7 @@ func addStatusInfo(md *strings.Builder, report *MainReport, titleCaser cases.Cas
 
 	// Add sync status
 	if report.SyncStatus.Status != "" {
-		statusIcon := "✅"
-		if report.SyncStatus.Status == "timeout" {
-			statusIcon = "⏰"
-		} else if report.SyncStatus.Status != "success" {
-			statusIcon = "❌"
-		}
-		fmt.Fprintf(md, "| **Status** | %s %s |\n", statusIcon, titleCaser.String(report.SyncStatus.Status))
+		fmt.Fprintf(md, "| **Status** | %s %s |\n", statusEmoji(report.SyncStatus.Status), titleCaser.String(report.SyncStatus.Status))
 	}
 
 	// Add status message if available
@@ -201,6 +247,18 @@ func addStatusInfo(md *strings.Builder, report *MainReport, titleCaser cases.Cas
 	md.WriteString("\n")
 }
 
+// statusEmoji returns the icon shown for a sync status
+func statusEmoji(status string) string {
+	switch status {
+	case "success":
+		return "✅"
+	case "timeout":
+		return "⏰"
+	default:
+		return "❌"
+	}
+}
+
 func addBasicInfo(md *strings.Builder, report *MainReport, titleCaser cases.Caser) {
 	md.WriteString("## 📋 Test Information\n\n")
 	md.WriteString("| Field | Value |\n")
@@ -503,6 +561,148 @@ func addFilesInfo(md *strings.Builder, report *MainReport, inputFile string) {
 	md.WriteString("\n")
 }
 
+// reportMilestonePercents are the milestones shown in the markdown summary
+var reportMilestonePercents = []int{50, 90, 100}
+
+// reportChartPoints limits the points per mermaid chart to keep summaries readable
+const reportChartPoints = 40
+
+func addProgressAnalysis(md *strings.Builder, result *report.Result) {
+	md.WriteString("## 📈 Sync Progress\n\n")
+
+	md.WriteString("### Milestones\n\n")
+	md.WriteString("| Progress | Block | Time |\n")
+	md.WriteString("|-------|-------|-------|\n")
+	for _, milestone := range report.ComputeMilestones(result, result.SyncStatus.Block, reportMilestonePercents) {
+		fmt.Fprintf(md, "| **%d%%** | %s | %s |\n", milestone.Percent, formatNumber(milestone.Block), formatElapsed(milestone.Elapsed))
+	}
+	md.WriteString("\n")
+
+	addPeakResourceUsage(md, result)
+
+	for _, chart := range report.ProgressCharts(result, reportChartPoints) {
+		switch chart.Title {
+		case "Block Height", "Disk Usage", "Memory Usage":
+			addMermaidChart(md, chart)
+		}
+	}
+}
+
+func addPeakResourceUsage(md *strings.Builder, result *report.Result) {
+	metrics := report.ComputeMetrics(result)
+	entries := result.SyncStatus.SyncProgress
+
+	md.WriteString("### Resource Usage\n\n")
+	md.WriteString("| Metric | Value | Trend |\n")
+	md.WriteString("|-------|-------|-------|\n")
+	for _, definition := range report.RunMetricDefinitions {
+		if definition.Name == "duration" {
+			continue
+		}
+		trend := "-"
+		if series, ok := progressSeries(entries, definition.Name); ok {
+			trend = sparkline(series)
+		}
+		fmt.Fprintf(md, "| **%s** | %s | %s |\n", definition.Label, formatMetricValue(definition.Unit, definition.Value(metrics)), trend)
+	}
+	md.WriteString("\n")
+}
+
+// progressSeries extracts the per-entry values behind a run metric, if it has any
+func progressSeries(entries []report.SyncProgressEntry, metric string) ([]float64, bool) {
+	var value func(report.SyncProgressEntry) float64
+	switch metric {
+	case "disk_el":
+		value = func(e report.SyncProgressEntry) float64 { return float64(e.DiskUsageExecutionClient) }
+	case "disk_cl":
+		value = func(e report.SyncProgressEntry) float64 { return float64(e.DiskUsageConsensusClient) }
+	case "peak_memory_el":
+		value = func(e report.SyncProgressEntry) float64 { return float64(e.MemoryUsageExecutionClient) }
+	case "peak_memory_cl":
+		value = func(e report.SyncProgressEntry) float64 { return float64(e.MemoryUsageConsensusClient) }
+	case "avg_cpu_el", "peak_cpu_el":
+		value = func(e report.SyncProgressEntry) float64 { return e.CPUUsagePercentExecutionClient }
+	case "avg_cpu_cl", "peak_cpu_cl":
+		value = func(e report.SyncProgressEntry) float64 { return e.CPUUsagePercentConsensusClient }
+	default:
+		return nil, false
+	}
+
+	values := make([]float64, 0, len(entries))
+	for _, entry := range entries {
+		values = append(values, value(entry))
+	}
+	return values, true
+}
+
+// sparkline renders values as a fixed-width row of block characters
+func sparkline(values []float64) string {
+	const width = 20
+	bars := []rune("▁▂▃▄▅▆▇█")
+
+	if len(values) == 0 {
+		return "-"
+	}
+
+	peak := 0.0
+	for _, value := range values {
+		peak = max(peak, value)
+	}
+
+	var line strings.Builder
+	buckets := min(width, len(values))
+	for i := range buckets {
+		// Take the bucket maximum so short spikes remain visible
+		bucket := 0.0
+		for _, value := range values[i*len(values)/buckets : (i+1)*len(values)/buckets] {
+			bucket = max(bucket, value)
+		}
+		level := 0
+		if peak > 0 {
+			level = int(bucket / peak * float64(len(bars)-1))
+		}
+		line.WriteRune(bars[level])
+	}
+	return line.String()
+}
+
+// addMermaidChart renders a chart as a mermaid xychart. Series share the y axis and are
+// listed in the title since xycharts have no legend.
+func addMermaidChart(md *strings.Builder, chart report.Chart) {
+	divisor, unit := 1.0, ""
+	if chart.Unit == report.ChartUnitBytes {
+		divisor, unit = 1<<30, " (GB)"
+	}
+
+	names := make([]string, 0, len(chart.Series))
+	maxX, maxY := 0.0, 0.0
+	for _, series := range chart.Series {
+		names = append(names, series.Name)
+		for _, point := range series.Points {
+			maxX = max(maxX, point.X)
+			maxY = max(maxY, point.Y/divisor)
+		}
+	}
+	// Mermaid rejects empty axis ranges, e.g. of series that are all zero
+	if maxY == 0 {
+		maxY = 1
+	}
+
+	fmt.Fprintf(md, "### %s\n\n", chart.Title)
+	md.WriteString("```mermaid\nxychart-beta\n")
+	fmt.Fprintf(md, "    title \"%s%s: %s\"\n", chart.Title, unit, strings.Join(names, ", "))
+	fmt.Fprintf(md, "    x-axis \"Elapsed (minutes)\" 0 --> %.0f\n", max(math.Ceil(maxX/60), 1))
+	fmt.Fprintf(md, "    y-axis 0 --> %s\n", strconv.FormatFloat(math.Ceil(maxY*1.05*10)/10, 'f', -1, 64))
+	for _, series := range chart.Series {
+		values := make([]string, 0, len(series.Points))
+		for _, point := range series.Points {
+			values = append(values, strconv.FormatFloat(math.Round(point.Y/divisor*100)/100, 'f', -1, 64))
+		}
+		fmt.Fprintf(md, "    line [%s]\n", strings.Join(values, ", "))
+	}
+	md.WriteString("```\n\n")
+}
+
 // Helper functions for formatting
 func formatDuration(d time.Duration) string {
 	switch {
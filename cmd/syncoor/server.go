@@ -12,9 +12,20 @@ import (
 type ServerConfig struct {
 	ListenAddr  string
 	AuthToken   string
+	TokensFile  string
+	ReadAuth    bool
+	Redact      bool
+	RedactNames []string
 	LogLevel    string
 	MockMode    bool
 	CORSOrigins string
+	ReportDir   string
+	Archive     bool
+	Storage     storageFlags
+	StoreFile   string
+	JobsFile    string
+	Store       api.StoreConfig
+	Runners     api.RunnerConfig
 }
 
 func NewServerCommand() *cobra.Command {
@@ -22,6 +33,9 @@ func NewServerCommand() *cobra.Command {
 		ListenAddr:  ":8080",
 		LogLevel:    "info",
 		CORSOrigins: "*",
+		Redact:      true,
+		Store:       api.DefaultStoreConfig(),
+		Runners:     api.DefaultRunnerConfig(),
 	}
 
 	cmd := &cobra.Command{
@@ -36,9 +50,29 @@ func NewServerCommand() *cobra.Command {
 	// Add flags
 	cmd.Flags().StringVar(&cfg.ListenAddr, "listen", ":8080", "Server listen address")
 	cmd.Flags().StringVar(&cfg.AuthToken, "auth-token", "", "Bearer token for authentication (optional)")
+	cmd.Flags().StringVar(&cfg.TokensFile, "tokens-file", "", "JSON file of named, scoped API tokens, updated by the admin API (optional)")
+	cmd.Flags().BoolVar(&cfg.ReadAuth, "require-read-auth", false, "Require a token with the read scope for tests, reports and events")
+	cmd.Flags().BoolVar(&cfg.Redact, "redact", true, "Mask secret-looking environment variables, flags and URL credentials of clients")
+	cmd.Flags().StringSliceVar(&cfg.RedactNames, "redact-pattern", nil,
+		"Additional regular expressions matching names of environment variables and flags to mask")
 	cmd.Flags().StringVar(&cfg.LogLevel, "log-level", "info", "Log level (debug, info, warn, error)")
 	cmd.Flags().BoolVar(&cfg.MockMode, "mock", false, "Run server in mock mode with generated test data")
 	cmd.Flags().StringVar(&cfg.CORSOrigins, "cors-origins", "*", "Comma-separated list of allowed CORS origins (* for all)")
+	cmd.Flags().StringVar(&cfg.ReportDir, "report-dir", "", "Directory of report files to serve (optional)")
+	cmd.Flags().BoolVar(&cfg.Archive, "archive", false,
+		"Write completed tests as reports into the report storage and regenerate its index (requires --report-dir or remote storage)")
+	cfg.Storage.register(cmd)
+	cmd.Flags().StringVar(&cfg.StoreFile, "store-file", "",
+		"File to persist tests to across restarts, progress updates of the last 5s may be lost on a crash (optional, defaults to in-memory)")
+	cmd.Flags().DurationVar(&cfg.Store.MaxAge, "store-max-age", cfg.Store.MaxAge, "Remove tests this long after their last update")
+	cmd.Flags().DurationVar(&cfg.Store.FinishedRetention, "store-finished-retention", cfg.Store.FinishedRetention,
+		"Remove finished and orphaned tests this long after their last update")
+	cmd.Flags().IntVar(&cfg.Store.MaxHistory, "store-max-history", cfg.Store.MaxHistory, "Maximum progress points kept per test")
+	cmd.Flags().StringVar(&cfg.JobsFile, "jobs-file", "", "File to persist the job queue to across restarts (optional, defaults to in-memory)")
+	cmd.Flags().DurationVar(&cfg.Runners.OfflineAfter, "runner-offline-after", cfg.Runners.OfflineAfter,
+		"Consider runners offline after this long without a heartbeat")
+	cmd.Flags().DurationVar(&cfg.Runners.Retention, "runner-retention", cfg.Runners.Retention,
+		"Remove runners this long after their last heartbeat")
 
 	return cmd
 }
@@ -56,9 +90,72 @@ func runServer(ctx context.Context, cfg *ServerConfig) error {
 	server := api.NewServer(log, cfg.ListenAddr, cfg.AuthToken)
 	server.SetCORSOrigins(cfg.CORSOrigins)
 
+	if cfg.TokensFile != "" {
+		tokens, err := api.LoadTokenStore(cfg.TokensFile)
+		if err != nil {
+			return err
+		}
+		if err := server.SetTokenStore(tokens); err != nil {
+			return fmt.Errorf("failed to add auth token: %w", err)
+		}
+	}
+
+	if cfg.ReadAuth {
+		if err := server.EnableReadAuth(); err != nil {
+			return err
+		}
+	}
+
+	if !cfg.Redact {
+		server.SetRedactor(nil)
+	} else if len(cfg.RedactNames) > 0 {
+		redactor, err := api.NewRedactor(append(api.DefaultRedactPatterns, cfg.RedactNames...))
+		if err != nil {
+			return err
+		}
+		server.SetRedactor(redactor)
+	}
+
+	if cfg.StoreFile != "" {
+		store, err := api.NewFileStore(log, cfg.StoreFile, cfg.Store)
+		if err != nil {
+			return fmt.Errorf("failed to open store: %w", err)
+		}
+		server.SetStore(store)
+	} else {
+		server.SetStore(api.NewMemoryStore(log, cfg.Store))
+	}
+
+	server.SetRunnerRegistry(api.NewRunnerRegistry(cfg.Runners))
+
+	if cfg.JobsFile != "" {
+		jobs, err := api.LoadJobQueue(cfg.JobsFile)
+		if err != nil {
+			return err
+		}
+		server.SetJobQueue(jobs)
+	}
+
+	// Serve report files from local or remote storage if configured
+	if cfg.ReportDir != "" || !cfg.Storage.isLocal() {
+		storage, err := cfg.Storage.build(cfg.ReportDir)
+		if err != nil {
+			return fmt.Errorf("failed to configure report storage: %w", err)
+		}
+		server.SetReportStorage(storage)
+		log.WithField("storage", storage.String()).Info("Serving report files")
+	}
+
+	if cfg.Archive {
+		if err := server.EnableReportArchive(); err != nil {
+			return fmt.Errorf("failed to enable report archival: %w", err)
+		}
+		log.Info("Archiving completed tests as reports")
+	}
+
 	log.WithField("addr", cfg.ListenAddr).Info("Starting syncoor server")
-	if cfg.AuthToken != "" {
-		log.Info("Authentication enabled")
+	if cfg.AuthToken != "" || cfg.TokensFile != "" {
+		log.WithField("read_auth", cfg.ReadAuth).Info("Authentication enabled")
 	}
 	if cfg.MockMode {
 		log.Info("Mock mode enabled - generating test data")
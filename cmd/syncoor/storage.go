@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+// ErrUnknownStorageBackend is returned when --storage names an unsupported backend
+var ErrUnknownStorageBackend = errors.New("unknown storage backend")
+
+// storageFlags holds the flags selecting where report files are stored
+type storageFlags struct {
+	backend       string
+	s3Endpoint    string
+	s3Region      string
+	s3Bucket      string
+	s3Prefix      string
+	s3AccessKeyID string
+	s3SecretKey   string
+	s3PathStyle   bool
+}
+
+// register adds the storage flags to a command
+func (f *storageFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.backend, "storage", "local", "Report storage backend (local, s3)")
+	cmd.Flags().StringVar(&f.s3Endpoint, "s3-endpoint", "", "S3-compatible endpoint URL (e.g., https://s3.us-east-1.amazonaws.com)")
+	cmd.Flags().StringVar(&f.s3Region, "s3-region", "us-east-1", "S3 region")
+	cmd.Flags().StringVar(&f.s3Bucket, "s3-bucket", "", "S3 bucket for reports")
+	cmd.Flags().StringVar(&f.s3Prefix, "s3-prefix", "", "Key prefix for reports within the bucket")
+	cmd.Flags().StringVar(&f.s3AccessKeyID, "s3-access-key-id", "", "S3 access key ID (defaults to AWS_ACCESS_KEY_ID)")
+	cmd.Flags().StringVar(&f.s3SecretKey, "s3-secret-access-key", "", "S3 secret access key (defaults to AWS_SECRET_ACCESS_KEY)")
+	cmd.Flags().BoolVar(&f.s3PathStyle, "s3-path-style", false, "Use path-style S3 URLs (required by MinIO and most S3-compatible servers)")
+}
+
+// isLocal reports whether reports are stored on the local filesystem
+func (f *storageFlags) isLocal() bool {
+	return f.backend == "" || f.backend == "local"
+}
+
+// build creates the configured storage backend, using localDir for the local backend
+func (f *storageFlags) build(localDir string) (report.Storage, error) {
+	switch f.backend {
+	case "", "local":
+		return report.NewLocalStorage(localDir), nil
+	case "s3":
+		accessKeyID := f.s3AccessKeyID
+		if accessKeyID == "" {
+			accessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		}
+		secretKey := f.s3SecretKey
+		if secretKey == "" {
+			secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		}
+
+		return report.NewS3Storage(report.S3Config{
+			Endpoint:        f.s3Endpoint,
+			Region:          f.s3Region,
+			Bucket:          f.s3Bucket,
+			Prefix:          f.s3Prefix,
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretKey,
+			PathStyle:       f.s3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStorageBackend, f.backend)
+	}
+}
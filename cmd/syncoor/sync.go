@@ -33,9 +33,12 @@ func NewSyncCommand() *cobra.Command {
 		networkName           string
 		enclaveName           string
 		reportDir             string
+		stateDir              string
 		labels                []string
 		serverURL             string
 		serverAuth            string
+		runnerID              string
+		runnerLabels          []string
 		enableRecovery        bool
 		clientLogs            bool
 		supernode             bool
@@ -52,6 +55,8 @@ func NewSyncCommand() *cobra.Command {
 		metricsExporterImage    string
 		metricsExporterPort     int
 		metricsExporterLogLevel string
+		// Report storage flags
+		reportStorage storageFlags
 	)
 
 	cmd := &cobra.Command{
@@ -95,8 +100,10 @@ Exit codes:
 				Network:                 networkName,
 				EnclaveName:             enclaveName,
 				ReportDir:               reportDir,
+				StateDir:                stateDir,
 				ServerURL:               serverURL,
 				ServerAuth:              serverAuth,
+				RunnerID:                runnerID,
 				ClientLogs:              clientLogs,
 				Supernode:               supernode,
 				CheckpointSyncEnabled:   checkpointSyncEnabled,
@@ -125,6 +132,18 @@ Exit codes:
 			}
 			config.Labels = parsedLabels
 
+			// Parse runner labels
+			parsedRunnerLabels := make(map[string]string)
+			for _, label := range runnerLabels {
+				parts := strings.SplitN(label, "=", 2)
+				if len(parts) == 2 {
+					parsedRunnerLabels[parts[0]] = parts[1]
+				} else {
+					logger.Warnf("Invalid runner label format '%s', skipping", label)
+				}
+			}
+			config.RunnerLabels = parsedRunnerLabels
+
 			// Parse EL environment variables
 			parsedELEnvVars := make(map[string]string)
 			for _, envVar := range elEnvVars {
@@ -163,6 +182,15 @@ Exit codes:
 				syncTestService.EnableRecovery(recoveryService)
 			}
 
+			// Write final reports to a remote backend if requested
+			if !reportStorage.isLocal() {
+				storage, err := reportStorage.build(reportDir)
+				if err != nil {
+					logger.Fatalf("Failed to configure report storage: %v", err)
+				}
+				syncTestService.EnableReportStorage(storage)
+			}
+
 			// Setup signal handling for graceful shutdown
 			setupSignalHandling(ctx, cancel, syncTestService, logger)
 
@@ -181,6 +209,9 @@ Exit codes:
 				if err == context.Canceled {
 					logger.Info("Context cancelled, shutting down...")
 					os.Exit(ExitCodeSuccess)
+				} else if errors.Is(err, synctest.ErrSyncCancelled) {
+					logger.Warnf("Sync test cancelled: %v", err)
+					os.Exit(ExitCodeSuccess)
 				} else if errors.Is(err, synctest.ErrSyncTimeout) {
 					logger.Errorf("Sync operation timed out: %v", err)
 					os.Exit(ExitCodeTimeout)
@@ -218,9 +249,13 @@ Exit codes:
 	cmd.Flags().StringVar(&networkName, "network", "hoodi", "Network to connect to (e.g., hoodi, sepolia, mainnet)")
 	cmd.Flags().StringVar(&enclaveName, "enclave", "", "Enclave name (optional - defaults to sync-test-$network-$el-client-$cl-client)")
 	cmd.Flags().StringVar(&reportDir, "report-dir", "./reports", "Directory to save reports (defaults to ./reports)")
+	cmd.Flags().StringVar(&stateDir, "state-dir", "", "Directory for temporary recovery reports (defaults to --report-dir)")
+	reportStorage.register(cmd)
 	cmd.Flags().StringSliceVar(&labels, "label", []string{}, "Labels in key=value format (can be used multiple times)")
 	cmd.Flags().StringVar(&serverURL, "server", "", "Centralized server URL (e.g., https://api.syncoor.example)")
 	cmd.Flags().StringVar(&serverAuth, "server-auth", "", "Bearer token for server authentication")
+	cmd.Flags().StringVar(&runnerID, "runner-id", "", "Identity of this runner on the server (defaults to the hostname)")
+	cmd.Flags().StringSliceVar(&runnerLabels, "runner-label", []string{}, "Runner labels in key=value format (can be used multiple times)")
 	cmd.Flags().BoolVar(&enableRecovery, "enable-recovery", true, "Enable recovery from interrupted sync operations")
 	cmd.Flags().BoolVar(&clientLogs, "client-logs", false, "Output EL and CL client logs to stdout")
 	cmd.Flags().BoolVar(&supernode, "supernode", false, "Enable supernode (should only be used with peerdas)")
@@ -261,13 +296,9 @@ func setupSignalHandling(ctx context.Context, cancel context.CancelFunc, service
 		case sig := <-sigChan:
 			logger.WithField("signal", sig).Info("Received signal, saving progress and shutting down")
 			// Set cancelled status before saving temp report
-			if reportService, ok := service.(interface {
-				SetSyncStatus(ctx context.Context, status string, message string) error
-			}); ok {
-				cancelMessage := fmt.Sprintf("Sync operation cancelled by %s signal", sig)
-				if err := reportService.SetSyncStatus(ctx, "cancelled", cancelMessage); err != nil {
-					logger.WithError(err).Warn("Failed to set cancelled status")
-				}
+			cancelMessage := fmt.Sprintf("Sync operation cancelled by %s signal", sig)
+			if err := service.SetSyncStatus(ctx, "cancelled", cancelMessage); err != nil {
+				logger.WithError(err).Warn("Failed to set cancelled status")
 			}
 			if err := service.SaveTempReport(ctx); err != nil {
 				logger.WithError(err).Error("Failed to save temp report")
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CreateTokenRequest is the body of POST /api/v1/admin/tokens
+type CreateTokenRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit float64  `json:"rate_limit,omitempty"`
+}
+
+// CreateTokenResponse returns the secret of a new token, it is not retrievable later
+type CreateTokenResponse struct {
+	APITokenInfo
+	Token string `json:"token"`
+}
+
+// errTokenAdminDisabled is returned by the admin API while authentication is disabled,
+// since creating the first token would lock out every other client
+var errTokenAdminDisabled = errors.New("token management requires authentication to be enabled")
+
+// handleTokens lists (GET) and creates (POST) API tokens
+func (s *Server) handleTokens(w http.ResponseWriter, r *http.Request) {
+	if !s.tokens.Enabled() {
+		s.writeError(w, errTokenAdminDisabled, http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, Response{Data: s.tokens.List()})
+	case http.MethodPost:
+		var req CreateTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.writeError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		secret, err := s.tokens.Create(req.Name, req.Scopes, req.RateLimit)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrTokenExists):
+				s.writeError(w, err, http.StatusConflict)
+			case errors.Is(err, ErrInvalidToken):
+				s.writeError(w, err, http.StatusBadRequest)
+			default:
+				s.writeError(w, err, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		s.log.WithFields(map[string]interface{}{
+			"name":       req.Name,
+			"scopes":     req.Scopes,
+			"created_by": tokenName(r),
+		}).Info("API token created")
+
+		s.writeJSON(w, http.StatusCreated, Response{Data: CreateTokenResponse{
+			APITokenInfo: APITokenInfo{Name: req.Name, Scopes: req.Scopes, RateLimit: req.RateLimit},
+			Token:        secret,
+		}})
+	default:
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+	}
+}
+
+// handleTokenRevoke revokes an API token by name (DELETE)
+func (s *Server) handleTokenRevoke(w http.ResponseWriter, r *http.Request) {
+	if !s.tokens.Enabled() {
+		s.writeError(w, errTokenAdminDisabled, http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodDelete {
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/tokens/")
+	if err := s.tokens.Revoke(name); err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			s.writeError(w, err, http.StatusNotFound)
+			return
+		}
+		s.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"name":       name,
+		"revoked_by": tokenName(r),
+	}).Info("API token revoked")
+
+	s.writeJSON(w, http.StatusOK, Response{Data: map[string]string{"status": "revoked"}})
+}
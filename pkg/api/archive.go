@@ -0,0 +1,305 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrReportStorageRequired = errors.New("report storage is required for archival")
+	ErrReportExists          = errors.New("report already exists")
+)
+
+// archiveIndexKey is the key of the index kept next to archived reports
+const archiveIndexKey = "index.json"
+
+// reportArchive writes reports to the report storage and keeps its index up to date,
+// so the server serves live and historical runs
+type reportArchive struct {
+	log          logrus.FieldLogger
+	storage      report.Storage
+	indexService report.IndexService
+
+	// mu serializes writes so index updates don't race; index is nil until the
+	// storage was scanned
+	mu    sync.Mutex
+	index *report.Index
+	wg    sync.WaitGroup
+}
+
+func newReportArchive(log logrus.FieldLogger, storage report.Storage) *reportArchive {
+	// An in-memory cache is never loaded, so it can't fail
+	cache, _ := report.NewIndexCache("")
+
+	indexService := report.NewIndexService(log)
+	indexService.SetCache(cache)
+
+	return &reportArchive{
+		log:          log.WithField("component", "archive"),
+		storage:      storage,
+		indexService: indexService,
+	}
+}
+
+// EnableReportArchive writes tests to the report storage as report files when they
+// complete and regenerates its index. SetReportStorage must be called first.
+func (s *Server) EnableReportArchive() error {
+	if s.archive == nil {
+		return ErrReportStorageRequired
+	}
+
+	s.archiveCompleted = true
+	return nil
+}
+
+// archiveTest archives a completed or orphaned test in the background
+func (s *Server) archiveTest(runID string) {
+	if !s.archiveCompleted {
+		return
+	}
+
+	test, err := s.store.GetTest(runID)
+	if err != nil {
+		s.log.WithError(err).WithField("run_id", runID).Error("Failed to load test for archival")
+		return
+	}
+
+	s.archive.wg.Add(1)
+	go func() {
+		defer s.archive.wg.Done()
+
+		err := s.archive.archive(context.Background(), test)
+		switch {
+		case errors.Is(err, ErrReportExists):
+			// Orphaned tests are archived before their runner may still complete them
+			s.log.WithField("run_id", runID).Warn("Test was already archived")
+		case err != nil:
+			s.log.WithError(err).WithField("run_id", runID).Error("Failed to archive test")
+		}
+	}()
+}
+
+// handleTestsOrphaned publishes the tests marked orphaned and archives them, they won't
+// be completed by their runner
+func (s *Server) handleTestsOrphaned(runIDs []string) {
+	s.publishTestsOrphaned(runIDs)
+	for _, runID := range runIDs {
+		s.archiveTest(runID)
+	}
+}
+
+// wait blocks until running archivals are done
+func (a *reportArchive) wait() {
+	a.wg.Wait()
+}
+
+func (a *reportArchive) archive(ctx context.Context, test *TestData) error {
+	_, err := a.save(ctx, testReport(test))
+	return err
+}
+
+// save writes a report with its progress entries and updates the index. Reports
+// are named like the ones written by runners.
+func (a *reportArchive) save(ctx context.Context, result *report.Result) (*report.IndexEntry, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.loadIndex(ctx); err != nil {
+		return nil, err
+	}
+	if findIndexEntry(a.index, result.RunID) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrReportExists, result.RunID)
+	}
+
+	baseName := fmt.Sprintf("%s_%s_%s", result.Network, result.ExecutionClientInfo.Type, result.ConsensusClientInfo.Type)
+	mainFileKey, err := report.WriteReport(ctx, a.storage, result, baseName)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := a.indexService.UpdateIndex(ctx, a.storage, []string{mainFileKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update index: %w", err)
+	}
+	if err := a.saveIndex(ctx, index); err != nil {
+		return nil, err
+	}
+
+	a.log.WithFields(logrus.Fields{
+		"run_id":    result.RunID,
+		"main_file": mainFileKey,
+		"entries":   len(result.SyncStatus.SyncProgress),
+	}).Info("Saved report")
+
+	return findIndexEntry(index, result.RunID), nil
+}
+
+// currentIndex returns the index of the report storage, scanning it on first use
+func (a *reportArchive) currentIndex(ctx context.Context) (*report.Index, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.loadIndex(ctx); err != nil {
+		return nil, err
+	}
+	return a.index, nil
+}
+
+// loadIndex scans the storage for existing reports once, later changes are
+// applied incrementally. Must be called with mu held.
+func (a *reportArchive) loadIndex(ctx context.Context) error {
+	if a.index != nil {
+		return nil
+	}
+
+	index, err := a.indexService.GenerateIndexFromStorage(ctx, a.storage)
+	if err != nil {
+		return fmt.Errorf("failed to generate index: %w", err)
+	}
+	return a.saveIndex(ctx, index)
+}
+
+// saveIndex writes the index next to the reports. Must be called with mu held.
+func (a *reportArchive) saveIndex(ctx context.Context, index *report.Index) error {
+	index.Aggregates = report.ComputeAggregates(index.Entries, report.DefaultTrendRuns)
+	if err := a.indexService.SaveIndexToStorage(ctx, index, a.storage, archiveIndexKey); err != nil {
+		return err
+	}
+	a.index = index
+	return nil
+}
+
+// findIndexEntry returns the index entry of a run, or nil
+func findIndexEntry(index *report.Index, runID string) *report.IndexEntry {
+	for i := range index.Entries {
+		if index.Entries[i].RunID == runID {
+			return &index.Entries[i]
+		}
+	}
+	return nil
+}
+
+// testReport converts the collected state of a completed test into a report. Only the
+// progress history kept by the store is included, the number of older points the store
+// dropped is recorded in the report.
+func testReport(test *TestData) *report.Result {
+	result := &report.Result{
+		RunID:               test.RunID,
+		Timestamp:           test.StartTime.Unix(),
+		Network:             test.Network,
+		Labels:              test.Labels,
+		SystemInfo:          test.SystemInfo,
+		ExecutionClientInfo: clientInfo(test.ELClient),
+		ConsensusClientInfo: clientInfo(test.CLClient),
+		SyncStatus: report.SyncStatus{
+			Start:        test.StartTime.Unix(),
+			Status:       testStatus(test),
+			SyncProgress: make([]report.SyncProgressEntry, 0, len(test.History)),
+		},
+	}
+
+	end := test.LastUpdate
+	if test.EndTime != nil {
+		end = *test.EndTime
+	}
+	result.SyncStatus.End = end.Unix()
+
+	if test.Error != "" {
+		result.SyncStatus.StatusMessage = test.Error
+	}
+
+	if metrics := test.CurrentMetrics; metrics != nil {
+		result.SyncStatus.Block = metrics.Block
+		result.SyncStatus.Slot = metrics.Slot
+		result.ExecutionClientInfo.Version = metrics.ExecVersion
+		result.ConsensusClientInfo.Version = metrics.ConsVersion
+	}
+
+	// Successful runs report the block and slot they synced to
+	if test.Success {
+		if test.FinalBlock > 0 || test.FinalSlot > 0 {
+			result.SyncStatus.Block = test.FinalBlock
+			result.SyncStatus.Slot = test.FinalSlot
+		}
+		result.SyncStatus.StatusMessage = fmt.Sprintf(
+			"Sync completed successfully at block %d, slot %d", result.SyncStatus.Block, result.SyncStatus.Slot,
+		)
+	}
+
+	for _, point := range test.History {
+		result.SyncStatus.SyncProgress = append(result.SyncStatus.SyncProgress, progressEntry(point.Timestamp, point.Metrics))
+	}
+	result.SyncStatus.EntriesCount = len(result.SyncStatus.SyncProgress)
+	result.SyncStatus.EntriesDropped = test.HistoryDropped
+
+	return result
+}
+
+// testStatus maps a completed test to a report status from its completion request
+func testStatus(test *TestData) string {
+	switch {
+	case test.Cancelled:
+		return "cancelled"
+	case test.TimedOut:
+		return "timeout"
+	case test.Success:
+		return "success"
+	default:
+		return "error"
+	}
+}
+
+func clientInfo(config reporting.ClientConfig) report.ClientInfo {
+	return report.ClientInfo{
+		Type:    config.Type,
+		Image:   config.Image,
+		Cmd:     config.Cmd,
+		EnvVars: config.EnvVars,
+	}
+}
+
+func progressEntry(timestamp time.Time, metrics reporting.ProgressMetrics) report.SyncProgressEntry {
+	return report.SyncProgressEntry{
+		T:                              timestamp.Unix(),
+		Block:                          metrics.Block,
+		Slot:                           metrics.Slot,
+		PeersExecutionClient:           metrics.ExecPeers,
+		PeersConsensusClient:           metrics.ConsPeers,
+		DiskUsageExecutionClient:       metrics.ExecDiskUsage,
+		MemoryUsageExecutionClient:     metrics.ExecMemoryUsage,
+		BlockIOReadExecutionClient:     metrics.ExecBlockIORead,
+		BlockIOWriteExecutionClient:    metrics.ExecBlockIOWrite,
+		CPUUsagePercentExecutionClient: metrics.ExecCPUUsagePercent,
+		DiskUsageConsensusClient:       metrics.ConsDiskUsage,
+		MemoryUsageConsensusClient:     metrics.ConsMemoryUsage,
+		BlockIOReadConsensusClient:     metrics.ConsBlockIORead,
+		BlockIOWriteConsensusClient:    metrics.ConsBlockIOWrite,
+		CPUUsagePercentConsensusClient: metrics.ConsCPUUsagePercent,
+	}
+}
+
+// progressMetrics is the inverse of progressEntry, fields a report entry doesn't have are taken from metrics
+func progressMetrics(entry report.SyncProgressEntry, metrics reporting.ProgressMetrics) reporting.ProgressMetrics {
+	metrics.Block = entry.Block
+	metrics.Slot = entry.Slot
+	metrics.ExecPeers = entry.PeersExecutionClient
+	metrics.ConsPeers = entry.PeersConsensusClient
+	metrics.ExecDiskUsage = entry.DiskUsageExecutionClient
+	metrics.ExecMemoryUsage = entry.MemoryUsageExecutionClient
+	metrics.ExecBlockIORead = entry.BlockIOReadExecutionClient
+	metrics.ExecBlockIOWrite = entry.BlockIOWriteExecutionClient
+	metrics.ExecCPUUsagePercent = entry.CPUUsagePercentExecutionClient
+	metrics.ConsDiskUsage = entry.DiskUsageConsensusClient
+	metrics.ConsMemoryUsage = entry.MemoryUsageConsensusClient
+	metrics.ConsBlockIORead = entry.BlockIOReadConsensusClient
+	metrics.ConsBlockIOWrite = entry.BlockIOWriteConsensusClient
+	metrics.ConsCPUUsagePercent = entry.CPUUsagePercentConsensusClient
+	return metrics
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestReport(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	test := &TestData{
+		RunID:      "run-1",
+		Network:    "hoodi",
+		StartTime:  start,
+		EndTime:    &end,
+		Success:    true,
+		FinalBlock: 120,
+		FinalSlot:  240,
+		ELClient:   reporting.ClientConfig{Type: "geth", Image: "geth:latest"},
+		CLClient:   reporting.ClientConfig{Type: "teku"},
+		CurrentMetrics: &reporting.ProgressMetrics{
+			Block: 100, Slot: 200, ExecVersion: "Geth/v1.15.0",
+		},
+		History: []ProgressPoint{
+			{Timestamp: start, Metrics: reporting.ProgressMetrics{Block: 1}},
+			{Timestamp: end, Metrics: reporting.ProgressMetrics{Block: 100, Slot: 200}},
+		},
+	}
+
+	result := testReport(test)
+	assert.Equal(t, "success", result.SyncStatus.Status)
+	assert.Equal(t, int64(1000), result.SyncStatus.Start)
+	assert.Equal(t, int64(2000), result.SyncStatus.End)
+	assert.Equal(t, uint64(120), result.SyncStatus.Block)
+	assert.Equal(t, "Sync completed successfully at block 120, slot 240", result.SyncStatus.StatusMessage)
+	assert.Equal(t, "Geth/v1.15.0", result.ExecutionClientInfo.Version)
+	assert.Equal(t, 2, result.SyncStatus.EntriesCount)
+	assert.Zero(t, result.SyncStatus.EntriesDropped)
+
+	test.HistoryDropped = 5
+	assert.Equal(t, 5, testReport(test).SyncStatus.EntriesDropped)
+
+	for _, tc := range []struct {
+		success   bool
+		timedOut  bool
+		cancelled bool
+		err       string
+		status    string
+	}{
+		{success: true, status: "success"},
+		{timedOut: true, err: "sync timed out after 1h", status: "timeout"},
+		{err: "client crashed", status: "error"},
+		{err: "the run timed out waiting for peers", status: "error"},
+		{cancelled: true, err: "sync operation cancelled by the server: wrong image", status: "cancelled"},
+	} {
+		test.Success = tc.success
+		test.TimedOut = tc.timedOut
+		test.Cancelled = tc.cancelled
+		test.Error = tc.err
+		result := testReport(test)
+		assert.Equal(t, tc.status, result.SyncStatus.Status, tc.err)
+		if tc.err != "" {
+			assert.Equal(t, tc.err, result.SyncStatus.StatusMessage)
+		}
+	}
+}
+
+func TestReportArchive(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(logrus.New(), "", "")
+	storage := report.NewLocalStorage(t.TempDir())
+	server.SetReportStorage(storage)
+	require.NoError(t, server.EnableReportArchive())
+
+	post := func(target, body string) {
+		rec := httptest.NewRecorder()
+		server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	post("/api/v1/tests/keepalive", `{"run_id": "run-1", "timestamp": 1000, "network": "hoodi",
+		"el_client": {"type": "geth"}, "cl_client": {"type": "teku"}}`)
+	post("/api/v1/tests/run-1/progress", `{"metrics": {"block": 50, "slot": 100}}`)
+	post("/api/v1/tests/run-1/complete", `{"timestamp": 2000, "success": true, "final_block": 120, "final_slot": 240}`)
+	server.archive.wait()
+
+	ctx := context.Background()
+	objects, err := storage.List(ctx, "")
+	require.NoError(t, err)
+	keys := make([]string, 0, len(objects))
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	require.Len(t, keys, 3, keys)
+	assert.Equal(t, archiveIndexKey, keys[0])
+	assert.True(t, strings.HasSuffix(keys[1], "hoodi_geth_teku.main.json"), keys[1])
+	assert.True(t, strings.HasSuffix(keys[2], "hoodi_geth_teku.progress.json"), keys[2])
+
+	mainData, err := storage.Read(ctx, keys[1])
+	require.NoError(t, err)
+	progressData, err := storage.Read(ctx, keys[2])
+	require.NoError(t, err)
+	result, err := report.ParseReport(mainData, progressData)
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", result.RunID)
+	assert.Equal(t, "success", result.SyncStatus.Status)
+	assert.Equal(t, uint64(120), result.SyncStatus.Block)
+	assert.Equal(t, uint64(240), result.SyncStatus.Slot)
+	assert.Equal(t, int64(2000), result.SyncStatus.End)
+	require.Len(t, result.SyncStatus.SyncProgress, 1)
+	assert.Equal(t, uint64(50), result.SyncStatus.SyncProgress[0].Block)
+
+	indexData, err := storage.Read(ctx, archiveIndexKey)
+	require.NoError(t, err)
+	var index report.Index
+	require.NoError(t, json.Unmarshal(indexData, &index))
+	require.Len(t, index.Entries, 1)
+	assert.Equal(t, "run-1", index.Entries[0].RunID)
+	assert.Equal(t, keys[1], index.Entries[0].MainFile)
+	assert.Equal(t, keys[2], index.Entries[0].ProgressFile)
+	assert.Equal(t, "success", index.Entries[0].SyncInfo.Status)
+
+	// Orphaned tests are archived without being completed
+	post("/api/v1/tests/keepalive", `{"run_id": "run-2", "timestamp": 3000, "network": "hoodi",
+		"el_client": {"type": "geth"}, "cl_client": {"type": "teku"}}`)
+	server.handleTestsOrphaned([]string{"run-2"})
+	server.archive.wait()
+
+	index2, err := server.archive.currentIndex(ctx)
+	require.NoError(t, err)
+	require.Len(t, index2.Entries, 2)
+	orphaned := findIndexEntry(index2, "run-2")
+	require.NotNil(t, orphaned)
+	assert.Equal(t, "error", orphaned.SyncInfo.Status)
+}
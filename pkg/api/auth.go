@@ -1,22 +1,59 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
 
+var (
+	ErrReadAuthRequiresTokens = errors.New("read authentication requires API tokens")
+	ErrTestNotOwned           = errors.New("test was created by another token")
+)
+
+// contextKey is the type of request context keys set by the server
+type contextKey string
+
+// tokenContextKey holds the token that authenticated a request
+const tokenContextKey contextKey = "token"
+
+// authMiddleware requires a token with the report-write scope
 func (s *Server) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return s.requireScope(ScopeReportWrite, next)
+}
+
+// readMiddleware requires a token with the read scope if read auth is enabled
+func (s *Server) readMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.readAuth {
+			next.ServeHTTP(w, r)
+			return
+		}
+		s.requireScope(ScopeRead, next)(w, r)
+	}
+}
+
+// requireScope requires a valid, rate limited bearer token granting scope. Requests
+// pass unauthenticated if no tokens are configured. Browsers can't set headers on
+// event streams, so the token may also be given as access_token query parameter.
+func (s *Server) requireScope(scope string, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Skip auth if no token configured
-		if s.authToken == "" {
+		if !s.tokens.Enabled() {
 			next.ServeHTTP(w, r)
 			return
 		}
 
 		// Check Authorization header
 		auth := r.Header.Get("Authorization")
+		if auth == "" && r.URL.Query().Get("access_token") != "" {
+			auth = "Bearer " + r.URL.Query().Get("access_token")
+		}
 		if auth == "" {
 			s.writeError(w, fmt.Errorf("missing authorization header"), http.StatusUnauthorized)
 			return
@@ -24,49 +61,57 @@ func (s *Server) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
 
 		// Validate bearer token
 		parts := strings.SplitN(auth, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" || parts[1] != s.authToken {
+		if len(parts) != 2 || parts[0] != "Bearer" {
 			s.writeError(w, fmt.Errorf("invalid authorization token"), http.StatusUnauthorized)
 			return
 		}
-
-		next.ServeHTTP(w, r)
-	}
-}
-
-func (s *Server) corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		origin := r.Header.Get("Origin")
-
-		// Set CORS headers
-		if s.corsOrigins == "*" {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-		} else if origin != "" {
-			// Check if origin is in allowed list
-			allowedOrigins := strings.Split(s.corsOrigins, ",")
-			for _, allowedOrigin := range allowedOrigins {
-				allowedOrigin = strings.TrimSpace(allowedOrigin)
-				if allowedOrigin == origin {
-					w.Header().Set("Access-Control-Allow-Origin", origin)
-					break
-				}
-			}
+		token, ok := s.tokens.Authenticate(parts[1])
+		if !ok {
+			s.writeError(w, fmt.Errorf("invalid authorization token"), http.StatusUnauthorized)
+			return
 		}
 
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Max-Age", "86400")
+		if !token.hasScope(scope) {
+			s.writeError(w, fmt.Errorf("token %s lacks the %s scope", token.Name, scope), http.StatusForbidden)
+			return
+		}
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
+		if allowed, retryAfter := s.tokens.Allow(token); !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			s.writeError(w, fmt.Errorf("rate limit exceeded for token %s", token.Name), http.StatusTooManyRequests)
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tokenContextKey, token)))
+	}
+}
+
+// requestToken returns the token that authenticated a request, or nil
+func requestToken(r *http.Request) *APIToken {
+	token, _ := r.Context().Value(tokenContextKey).(*APIToken)
+	return token
+}
+
+// tokenName returns the name of the token that authenticated a request, or empty
+func tokenName(r *http.Request) string {
+	if token := requestToken(r); token != nil {
+		return token.Name
+	}
+	return ""
+}
+
+// ownsTest reports whether a request may update a test. Tests are updated by the token
+// that created them; tokens with the admin scope, like the legacy token, update any test.
+func ownsTest(r *http.Request, test *TestData) bool {
+	token := requestToken(r)
+	if token == nil || test.CreatedBy == "" || token.hasScope(ScopeAdmin) {
+		return true
 	}
+	return token.Name == test.CreatedBy
 }
 
-func (s *Server) corsHandlerWrapper(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		origin := r.Header.Get("Origin")
 
 		// Set CORS headers
@@ -94,7 +139,7 @@ func (s *Server) corsHandlerWrapper(next http.Handler) http.Handler {
 		}
 
 		next.ServeHTTP(w, r)
-	})
+	}
 }
 
 func (s *Server) loggingMiddleware(next http.HandlerFunc) http.HandlerFunc {
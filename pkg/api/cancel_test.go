@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestCancel(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(logrus.New(), "", "secret")
+	httpServer := httptest.NewServer(server.router)
+	defer httpServer.Close()
+
+	cancel := func(runID string) int {
+		req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/api/v1/tests/"+runID+"/cancel", strings.NewReader(`{"reason":"wrong image"}`))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	ctx := context.Background()
+	client := reporting.NewClient(httpServer.URL, "secret", logrus.New())
+	client.Start(ctx)
+	defer client.Stop()
+
+	require.NoError(t, client.ReportTestKeepAlive(ctx, reporting.TestKeepaliveRequest{
+		RunID: "run-1", Timestamp: time.Now().Unix(), Network: "hoodi", RunnerID: "box-1",
+	}))
+	assert.Equal(t, http.StatusNotFound, cancel("run-2"))
+	assert.Equal(t, http.StatusAccepted, cancel("run-1"))
+
+	// The runner learns about the cancellation from its next update
+	client.ReportProgress(reporting.ProgressMetrics{Block: 1})
+	select {
+	case <-client.Cancelled():
+	case <-time.After(5 * time.Second):
+		t.Fatal("client wasn't cancelled")
+	}
+	assert.Equal(t, "wrong image", client.CancelReason())
+
+	require.NoError(t, client.ReportTestComplete(ctx, reporting.TestCompleteRequest{
+		Timestamp: time.Now().Unix(), Cancelled: true, Error: "sync operation cancelled by the server: wrong image",
+	}))
+	assert.Equal(t, http.StatusConflict, cancel("run-1"))
+
+	detail, err := server.store.GetTestDetail("run-1")
+	require.NoError(t, err)
+	assert.True(t, detail.CancelRequested)
+	assert.Equal(t, "default", detail.CancelRequestedBy)
+	assert.Equal(t, TestStatusCancelled, testListStatus(detail.TestSummary))
+}
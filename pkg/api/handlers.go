@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/ethpandaops/syncoor/pkg/report"
 	"github.com/ethpandaops/syncoor/pkg/reporting"
 )
 
@@ -28,6 +30,10 @@ func (s *Server) handleTestKeepalive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Secrets never reach the store
+	req.ELClient = s.redactor.ClientConfig(req.ELClient)
+	req.CLClient = s.redactor.ClientConfig(req.CLClient)
+
 	s.log.WithFields(map[string]interface{}{
 		"run_id":          req.RunID,
 		"network":         req.Network,
@@ -42,12 +48,16 @@ func (s *Server) handleTestKeepalive(w http.ResponseWriter, r *http.Request) {
 		"cl_client_envs":  len(req.CLClient.EnvVars),
 	}).Debug("Test keepalive received")
 
+	if !s.checkTestOwner(w, r, req.RunID) {
+		return
+	}
+
 	// Try to update existing test keepalive timestamp
 	if err := s.store.UpdateTestKeepalive(req); err != nil {
 		// If test not found, try to create it
 		if errors.Is(err, ErrTestNotFound) {
 			s.log.WithField("run_id", req.RunID).Info("Test not found, creating new test")
-			if createErr := s.store.CreateTest(req); createErr != nil {
+			if createErr := s.store.CreateTest(req, tokenName(r)); createErr != nil {
 				s.log.WithFields(map[string]interface{}{
 					"run_id": req.RunID,
 					"error":  createErr.Error(),
@@ -55,6 +65,12 @@ func (s *Server) handleTestKeepalive(w http.ResponseWriter, r *http.Request) {
 				s.writeError(w, createErr, http.StatusInternalServerError)
 				return
 			}
+			if req.RunnerID != "" {
+				s.runners.StartRun(req)
+			}
+			if test, getErr := s.store.GetTest(req.RunID); getErr == nil {
+				s.publishTestStart(test)
+			}
 		} else {
 			s.log.WithFields(map[string]interface{}{
 				"run_id": req.RunID,
@@ -63,9 +79,38 @@ func (s *Server) handleTestKeepalive(w http.ResponseWriter, r *http.Request) {
 			s.writeError(w, err, http.StatusInternalServerError)
 			return
 		}
+	} else {
+		s.publishTestKeepalive(req.RunID, time.Unix(req.Timestamp, 0))
+	}
+
+	s.writeJSON(w, http.StatusOK, Response{Data: s.testControl(req.RunID, "acknowledged")})
+}
+
+// checkTestOwner rejects updates of a test by tokens other than the one that created it.
+// Tests that don't exist pass, the update itself reports them.
+func (s *Server) checkTestOwner(w http.ResponseWriter, r *http.Request, runID string) bool {
+	test, err := s.store.GetTest(runID)
+	if err != nil || ownsTest(r, test) {
+		return true
 	}
 
-	s.writeJSON(w, http.StatusOK, Response{Data: map[string]string{"status": "acknowledged"}})
+	s.log.WithFields(map[string]interface{}{
+		"run_id":     runID,
+		"token":      tokenName(r),
+		"created_by": test.CreatedBy,
+	}).Warn("Rejected update of a test created by another token")
+	s.writeError(w, fmt.Errorf("%w: %s", ErrTestNotOwned, runID), http.StatusForbidden)
+	return false
+}
+
+// testControl acknowledges a runner update, telling the runner if the test was cancelled
+func (s *Server) testControl(runID, status string) reporting.TestControlResponse {
+	control := reporting.TestControlResponse{Status: status}
+	if test, err := s.store.GetTest(runID); err == nil && test.CancelRequestedAt != nil {
+		control.Cancel = true
+		control.CancelReason = test.CancelReason
+	}
+	return control
 }
 
 func (s *Server) handleTestOperations(w http.ResponseWriter, r *http.Request) {
@@ -78,14 +123,16 @@ func (s *Server) handleTestOperations(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
 		if strings.HasSuffix(r.URL.Path, "/progress") {
-			s.handleTestProgress(w, r, runID)
+			s.authMiddleware(func(w http.ResponseWriter, r *http.Request) { s.handleTestProgress(w, r, runID) })(w, r)
 		} else if strings.HasSuffix(r.URL.Path, "/complete") {
-			s.handleTestComplete(w, r, runID)
+			s.authMiddleware(func(w http.ResponseWriter, r *http.Request) { s.handleTestComplete(w, r, runID) })(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/cancel") {
+			s.requireScope(ScopeJobWrite, func(w http.ResponseWriter, r *http.Request) { s.handleTestCancel(w, r, runID) })(w, r)
 		} else {
 			s.writeError(w, fmt.Errorf("invalid endpoint"), http.StatusNotFound)
 		}
 	case http.MethodGet:
-		s.handleTestDetail(w, r, runID)
+		s.readMiddleware(func(w http.ResponseWriter, r *http.Request) { s.handleTestDetail(w, r, runID) })(w, r)
 	default:
 		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
 	}
@@ -98,6 +145,10 @@ func (s *Server) handleTestProgress(w http.ResponseWriter, r *http.Request, runI
 		return
 	}
 
+	if !s.checkTestOwner(w, r, runID) {
+		return
+	}
+
 	s.log.WithFields(map[string]interface{}{
 		"run_id":            runID,
 		"block":             req.Metrics.Block,
@@ -128,7 +179,7 @@ func (s *Server) handleTestProgress(w http.ResponseWriter, r *http.Request, runI
 	// Publish SSE event
 	s.publishTestProgress(runID, &req.Metrics)
 
-	s.writeJSON(w, http.StatusOK, Response{Data: map[string]string{"status": "updated"}})
+	s.writeJSON(w, http.StatusOK, Response{Data: s.testControl(runID, "updated")})
 }
 
 func (s *Server) handleTestComplete(w http.ResponseWriter, r *http.Request, runID string) {
@@ -138,6 +189,10 @@ func (s *Server) handleTestComplete(w http.ResponseWriter, r *http.Request, runI
 		return
 	}
 
+	if !s.checkTestOwner(w, r, runID) {
+		return
+	}
+
 	logFields := map[string]interface{}{
 		"run_id":      runID,
 		"success":     req.Success,
@@ -149,6 +204,11 @@ func (s *Server) handleTestComplete(w http.ResponseWriter, r *http.Request, runI
 	}
 	s.log.WithFields(logFields).Info("Test completed")
 
+	var previousStatus string
+	if test, err := s.store.GetTest(runID); err == nil {
+		previousStatus = test.status()
+	}
+
 	if err := s.store.CompleteTest(runID, req); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			s.log.WithField("run_id", runID).Info("Couldn't find test to complete")
@@ -165,11 +225,55 @@ func (s *Server) handleTestComplete(w http.ResponseWriter, r *http.Request, runI
 	}
 
 	// Publish SSE event
-	s.publishTestComplete(runID, req.Success, req.Error)
+	s.publishTestComplete(runID, previousStatus, req)
+
+	if test, err := s.store.GetTest(runID); err == nil && test.RunnerID != "" {
+		s.runners.FinishRun(test.RunnerID, runID, req)
+	}
+
+	// Write the test to the report storage if archival is enabled
+	s.archiveTest(runID)
 
 	s.writeJSON(w, http.StatusOK, Response{Data: map[string]string{"status": "completed"}})
 }
 
+// handleTestCancel cancels a running test. The runner learns about it from the response
+// to its next keepalive or progress update and completes the test as cancelled.
+func (s *Server) handleTestCancel(w http.ResponseWriter, r *http.Request, runID string) {
+	var req reporting.TestCancelRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.writeError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Reason == "" {
+		req.Reason = "cancelled through the API"
+	}
+
+	if err := s.store.RequestCancel(runID, req.Reason, tokenName(r)); err != nil {
+		switch {
+		case errors.Is(err, ErrTestNotFound):
+			s.writeError(w, err, http.StatusNotFound)
+		case errors.Is(err, ErrTestNotRunning):
+			s.writeError(w, err, http.StatusConflict)
+		default:
+			s.writeError(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"run_id":       runID,
+		"reason":       req.Reason,
+		"requested_by": tokenName(r),
+	}).Info("Test cancel requested")
+
+	s.publishTestCancel(runID, req.Reason)
+
+	s.writeJSON(w, http.StatusAccepted, Response{Data: map[string]string{"status": "cancel_requested"}})
+}
+
 // Public endpoints
 func (s *Server) handleTestList(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -177,15 +281,19 @@ func (s *Server) handleTestList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check for activeOnly query parameter
-	activeOnly := r.URL.Query().Get("active") == "true"
-
-	tests := s.store.ListTests(activeOnly)
+	query, err := parseTestQuery(r.URL.Query())
+	if err != nil {
+		s.writeError(w, err, http.StatusBadRequest)
+		return
+	}
 
-	response := TestListResponse{
-		Tests:       tests,
-		TotalCount:  len(tests),
-		ActiveCount: s.countActiveTests(tests),
+	response, err := query.Apply(s.store.ListTests(query.Status == TestStatusRunning))
+	if err != nil {
+		s.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+	for i := range response.Tests {
+		response.Tests[i] = s.redactor.TestSummary(response.Tests[i])
 	}
 
 	s.writeJSON(w, http.StatusOK, Response{Data: response})
@@ -198,6 +306,21 @@ func (s *Server) handleTestDetail(w http.ResponseWriter, r *http.Request, runID
 		return
 	}
 
+	// Optionally downsample the progress history (e.g. ?resolution=1m)
+	if resolution := r.URL.Query().Get("resolution"); resolution != "" {
+		interval, err := time.ParseDuration(resolution)
+		if err != nil || interval < time.Second {
+			s.writeError(w, fmt.Errorf("invalid resolution: %s", resolution), http.StatusBadRequest)
+			return
+		}
+		detail.ProgressHistory = downsampleHistory(detail.ProgressHistory, interval)
+	}
+
+	// Tests may have been stored before redaction was configured
+	detail.TestSummary = s.redactor.TestSummary(detail.TestSummary)
+	detail.ELClientConfig = s.redactor.ClientConfig(detail.ELClientConfig)
+	detail.CLClientConfig = s.redactor.ClientConfig(detail.CLClientConfig)
+
 	s.writeJSON(w, http.StatusOK, Response{Data: detail})
 }
 
@@ -237,12 +360,27 @@ func getRunID(r *http.Request) string {
 	return ""
 }
 
-func (s *Server) countActiveTests(tests []TestSummary) int {
-	count := 0
-	for _, test := range tests {
-		if test.IsRunning {
-			count++
+// downsampleHistory reduces progress history to one point per interval bucket like
+// report files, see report.DownsampleProgress. Sync percentages and versions are taken
+// from the last point of each bucket.
+func downsampleHistory(history []ProgressPoint, interval time.Duration) []ProgressPoint {
+	entries := make([]report.SyncProgressEntry, 0, len(history))
+	for _, point := range history {
+		entries = append(entries, progressEntry(point.Timestamp, point.Metrics))
+	}
+
+	downsampled := report.DownsampleProgress(entries, interval)
+	result := make([]ProgressPoint, 0, len(downsampled))
+	last := 0
+	for _, entry := range downsampled {
+		// Each downsampled entry has the timestamp of the last point of its bucket
+		for last < len(history)-1 && history[last+1].Timestamp.Unix() <= entry.T {
+			last++
 		}
+		point := history[last]
+		point.Metrics = progressMetrics(entry, point.Metrics)
+		result = append(result, point)
 	}
-	return count
+
+	return result
 }
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestDetailResolution(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(logrus.New(), "", "")
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/tests/keepalive", strings.NewReader(
+		`{"run_id": "run-1", "timestamp": 1000, "network": "hoodi", "el_client": {"type": "geth"}, "cl_client": {"type": "teku"}}`)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Two minutes of progress, the memory peak is in the middle of the first minute
+	start := time.Unix(1200, 0)
+	store := server.store.(*MemoryStore)
+	store.mu.Lock()
+	for i, memory := range []uint64{100, 900, 200, 300, 400} {
+		store.tests["run-1"].History = append(store.tests["run-1"].History, ProgressPoint{
+			Timestamp: start.Add(time.Duration(i) * 25 * time.Second),
+			Metrics:   reporting.ProgressMetrics{Block: uint64(i + 1), ExecMemoryUsage: memory, ExecSyncPercent: float64(i * 10)},
+		})
+	}
+	store.mu.Unlock()
+
+	get := func(query string) (int, []ProgressPoint) {
+		rec := httptest.NewRecorder()
+		server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/tests/run-1"+query, nil))
+		var response struct {
+			Data TestDetail `json:"data"`
+		}
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		}
+		return rec.Code, response.Data.ProgressHistory
+	}
+
+	code, history := get("")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, history, 5)
+
+	code, history = get("?resolution=1m")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, history, 2)
+	assert.Equal(t, start.Add(50*time.Second).Unix(), history[0].Timestamp.Unix())
+	assert.Equal(t, uint64(3), history[0].Metrics.Block)
+	assert.Equal(t, uint64(900), history[0].Metrics.ExecMemoryUsage)
+	assert.InDelta(t, 20.0, history[0].Metrics.ExecSyncPercent, 0)
+	assert.Equal(t, uint64(5), history[1].Metrics.Block)
+	assert.Equal(t, uint64(400), history[1].Metrics.ExecMemoryUsage)
+
+	for _, invalid := range []string{"?resolution=fast", "?resolution=-1m", "?resolution=10ms"} {
+		code, _ = get(invalid)
+		assert.Equal(t, http.StatusBadRequest, code, invalid)
+	}
+}
@@ -0,0 +1,565 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+)
+
+var (
+	ErrJobNotFound   = errors.New("job not found")
+	ErrJobNotRunning = errors.New("job is not running")
+	ErrInvalidJob    = errors.New("invalid job")
+)
+
+// Job statuses
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled" // Its test was cancelled through the API
+)
+
+// maxFinishedJobs is the number of finished jobs kept for the job list
+const maxFinishedJobs = 500
+
+// jobAssignmentGrace is how long a runner has to report the run of a job it took in its
+// heartbeats. Jobs whose run is missing afterwards were lost, e.g. the poll response
+// never reached the runner.
+const jobAssignmentGrace = 2 * reporting.DefaultHeartbeatInterval
+
+// Job is a sync test queued on the server, run by the first matching runner that polls
+type Job struct {
+	ID         string            `json:"id"`
+	Spec       reporting.JobSpec `json:"spec"`
+	Status     string            `json:"status"`
+	RunID      string            `json:"run_id,omitempty"` // Assigned when a runner takes the job
+	RunnerID   string            `json:"runner_id,omitempty"`
+	CreatedBy  string            `json:"created_by,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	AssignedAt *time.Time        `json:"assigned_at,omitempty"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// jobsFile is the on-disk format of a JobQueue
+type jobsFile struct {
+	Jobs []*Job `json:"jobs"`
+}
+
+// JobQueue holds jobs in submission order and hands them to polling runners
+type JobQueue struct {
+	mu   sync.Mutex
+	path string // Empty if jobs are not persisted
+	jobs []*Job
+
+	// queued is closed and replaced when a job is queued, to wake up polling runners
+	queued    chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewJobQueue() *JobQueue {
+	return &JobQueue{queued: make(chan struct{}), closed: make(chan struct{})}
+}
+
+// LoadJobQueue loads jobs from a JSON file, which need not exist, and writes changes back to it
+func LoadJobQueue(path string) (*JobQueue, error) {
+	queue := NewJobQueue()
+	queue.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return queue, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jobs file: %w", err)
+	}
+
+	var file jobsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode jobs file: %w", err)
+	}
+	queue.jobs = file.Jobs
+
+	return queue, nil
+}
+
+// Submit queues a job
+func (q *JobQueue) Submit(spec reporting.JobSpec, createdBy string) (*Job, error) {
+	if spec.Network == "" || spec.ELClient == "" || spec.CLClient == "" {
+		return nil, fmt.Errorf("%w: network, el_client and cl_client are required", ErrInvalidJob)
+	}
+
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate job ID: %w", err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job := &Job{
+		ID:        hex.EncodeToString(idBytes),
+		Spec:      spec,
+		Status:    JobStatusQueued,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	q.jobs = append(q.jobs, job)
+	if err := q.save(); err != nil {
+		return nil, err
+	}
+
+	close(q.queued)
+	q.queued = make(chan struct{})
+
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+// Take assigns the oldest queued job whose runner labels the runner has, waiting up to
+// wait for one to be queued. It returns reporting.ErrNoJob if none was.
+func (q *JobQueue) Take(ctx context.Context, runnerID string, labels map[string]string, wait time.Duration) (*Job, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		q.mu.Lock()
+		for _, job := range q.jobs {
+			if job.Status != JobStatusQueued || !matchesRunnerLabels(job.Spec.RunnerLabels, labels) {
+				continue
+			}
+
+			now := time.Now().UTC()
+			job.Status = JobStatusRunning
+			job.RunnerID = runnerID
+			job.AssignedAt = &now
+			job.RunID = fmt.Sprintf("sync-test-%d-%s_%s_%s", now.UnixNano(), job.Spec.Network, job.Spec.ELClient, job.Spec.CLClient)
+			err := q.save()
+			jobCopy := *job
+			q.mu.Unlock()
+
+			return &jobCopy, err
+		}
+		queued := q.queued
+		q.mu.Unlock()
+
+		select {
+		case <-queued:
+		case <-timer.C:
+			return nil, reporting.ErrNoJob
+		case <-ctx.Done():
+			return nil, reporting.ErrNoJob
+		case <-q.closed:
+			return nil, reporting.ErrNoJob
+		}
+	}
+}
+
+// Close ends pending polls, so the server can shut down
+func (q *JobQueue) Close() {
+	q.closeOnce.Do(func() { close(q.closed) })
+}
+
+// Complete records the result a runner reported for a job
+func (q *JobQueue) Complete(id string, req reporting.JobCompleteRequest) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job := q.find(id)
+	if job == nil {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	if job.Status != JobStatusRunning {
+		return nil, fmt.Errorf("%w: %s is %s", ErrJobNotRunning, id, job.Status)
+	}
+	if job.RunnerID != req.RunnerID {
+		return nil, fmt.Errorf("%w: %s is running on %s", ErrJobNotRunning, id, job.RunnerID)
+	}
+
+	status := JobStatusCompleted
+	switch {
+	case req.Cancelled:
+		status = JobStatusCancelled
+	case !req.Success:
+		status = JobStatusFailed
+	}
+	q.finish(job, status, req.Error)
+	q.trim()
+	if err := q.save(); err != nil {
+		return nil, err
+	}
+
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+// FailOrphaned fails running jobs of runners that went offline, they would never complete
+func (q *JobQueue) FailOrphaned(offline func(runnerID string) bool) []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var failed []string
+	for _, job := range q.jobs {
+		if job.Status == JobStatusRunning && offline(job.RunnerID) {
+			q.finish(job, JobStatusFailed, fmt.Sprintf("runner %s went offline", job.RunnerID))
+			failed = append(failed, job.ID)
+		}
+	}
+	if len(failed) > 0 {
+		q.trim()
+		// Failing to persist is retried with the next change
+		_ = q.save()
+	}
+
+	return failed
+}
+
+// ReleaseMissing handles the running jobs of a runner whose runs are missing from its
+// heartbeat runIDs, once they were assigned longer than grace ago. Jobs whose run never
+// started are queued again, the others failed. It returns the IDs of both.
+func (q *JobQueue) ReleaseMissing(
+	runnerID string, runIDs []string, grace time.Duration, started func(runID string) bool,
+) (requeued, failed []string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	reported := make(map[string]bool, len(runIDs))
+	for _, runID := range runIDs {
+		reported[runID] = true
+	}
+
+	deadline := time.Now().Add(-grace)
+	for _, job := range q.jobs {
+		if job.Status != JobStatusRunning || job.RunnerID != runnerID || reported[job.RunID] ||
+			job.AssignedAt == nil || job.AssignedAt.After(deadline) {
+			continue
+		}
+
+		if started(job.RunID) {
+			q.finish(job, JobStatusFailed, fmt.Sprintf("runner %s stopped reporting run %s", runnerID, job.RunID))
+			failed = append(failed, job.ID)
+			continue
+		}
+
+		job.Status = JobStatusQueued
+		job.RunID = ""
+		job.RunnerID = ""
+		job.AssignedAt = nil
+		requeued = append(requeued, job.ID)
+	}
+
+	if len(requeued) > 0 {
+		close(q.queued)
+		q.queued = make(chan struct{})
+	}
+	if len(requeued) > 0 || len(failed) > 0 {
+		q.trim()
+		// Failing to persist is retried with the next change
+		_ = q.save()
+	}
+
+	return requeued, failed
+}
+
+// Get returns a job by ID
+func (q *JobQueue) Get(id string) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job := q.find(id)
+	if job == nil {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+// List returns all jobs, most recent first
+func (q *JobQueue) List() []Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	jobs := make([]Job, len(q.jobs))
+	for i, job := range q.jobs {
+		jobs[len(q.jobs)-1-i] = *job
+	}
+	return jobs
+}
+
+func (q *JobQueue) finish(job *Job, status, errMsg string) {
+	now := time.Now().UTC()
+	job.FinishedAt = &now
+	job.Error = errMsg
+	job.Status = status
+}
+
+// trim drops the jobs that finished first beyond the limit. Must be called with mu held.
+func (q *JobQueue) trim() {
+	var finished []*Job
+	for _, job := range q.jobs {
+		if job.FinishedAt != nil {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= maxFinishedJobs {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool { return finished[i].FinishedAt.Before(*finished[j].FinishedAt) })
+	dropped := make(map[*Job]bool, len(finished)-maxFinishedJobs)
+	for _, job := range finished[:len(finished)-maxFinishedJobs] {
+		dropped[job] = true
+	}
+
+	kept := make([]*Job, 0, len(q.jobs)-len(dropped))
+	for _, job := range q.jobs {
+		if !dropped[job] {
+			kept = append(kept, job)
+		}
+	}
+	q.jobs = kept
+}
+
+func (q *JobQueue) find(id string) *Job {
+	for _, job := range q.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// save writes the jobs to their file, must be called with mu held
+func (q *JobQueue) save() error {
+	if q.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(jobsFile{Jobs: q.jobs}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode jobs: %w", err)
+	}
+
+	if err := report.WriteFileAtomic(q.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write jobs file: %w", err)
+	}
+
+	return nil
+}
+
+// matchesRunnerLabels reports whether a runner has all labels a job requires
+func matchesRunnerLabels(required, labels map[string]string) bool {
+	for key, value := range required {
+		if actual, ok := labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Long-polling limits of POST /api/v1/jobs/poll
+const (
+	defaultJobPollWait = 30 * time.Second
+	maxJobPollWait     = 60 * time.Second
+)
+
+// SetJobQueue replaces the default in-memory job queue
+func (s *Server) SetJobQueue(jobs *JobQueue) {
+	s.jobs = jobs
+}
+
+// failOrphanedJobs fails the jobs of runners that went offline
+func (s *Server) failOrphanedJobs() {
+	if failed := s.jobs.FailOrphaned(s.runners.Offline); len(failed) > 0 {
+		s.log.WithField("job_id", failed).Warn("Failed jobs of offline runners")
+	}
+}
+
+// releaseMissingJobs requeues or fails the jobs a runner took but doesn't report running
+func (s *Server) releaseMissingJobs(runnerID string, runIDs []string) {
+	started := func(runID string) bool {
+		_, err := s.store.GetTest(runID)
+		return err == nil
+	}
+
+	requeued, failed := s.jobs.ReleaseMissing(runnerID, runIDs, jobAssignmentGrace, started)
+	if len(requeued) > 0 {
+		s.log.WithFields(map[string]interface{}{
+			"runner_id": runnerID,
+			"job_id":    requeued,
+		}).Warn("Requeued jobs the runner never started")
+	}
+	if len(failed) > 0 {
+		s.log.WithFields(map[string]interface{}{
+			"runner_id": runnerID,
+			"job_id":    failed,
+		}).Warn("Failed jobs the runner stopped reporting")
+	}
+}
+
+// handleJobs lists (GET) and queues (POST) jobs
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.readMiddleware(s.handleJobList)(w, r)
+	case http.MethodPost:
+		s.requireScope(ScopeJobWrite, s.handleJobSubmit)(w, r)
+	default:
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleJobList(w http.ResponseWriter, _ *http.Request) {
+	s.failOrphanedJobs()
+
+	jobs := s.jobs.List()
+	for i := range jobs {
+		jobs[i] = s.redactor.Job(jobs[i])
+	}
+
+	s.writeJSON(w, http.StatusOK, Response{Data: jobs})
+}
+
+func (s *Server) handleJobSubmit(w http.ResponseWriter, r *http.Request) {
+	var spec reporting.JobSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		s.writeError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	job, err := s.jobs.Submit(spec, tokenName(r))
+	if err != nil {
+		if errors.Is(err, ErrInvalidJob) {
+			s.writeError(w, err, http.StatusBadRequest)
+			return
+		}
+		s.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"job_id":        job.ID,
+		"network":       spec.Network,
+		"el_client":     spec.ELClient,
+		"cl_client":     spec.CLClient,
+		"runner_labels": spec.RunnerLabels,
+	}).Info("Job queued")
+
+	s.writeJSON(w, http.StatusCreated, Response{Data: s.redactor.Job(*job)})
+}
+
+// handleJobPoll hands the oldest matching job to a runner, holding the request until
+// one is queued or the wait time passes
+func (s *Server) handleJobPoll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req reporting.JobPollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+		return
+	}
+	if req.RunnerID == "" {
+		s.writeError(w, fmt.Errorf("runner_id is required"), http.StatusBadRequest)
+		return
+	}
+
+	wait := defaultJobPollWait
+	if req.Wait > 0 {
+		wait = min(time.Duration(req.Wait)*time.Second, maxJobPollWait)
+	}
+
+	s.failOrphanedJobs()
+
+	job, err := s.jobs.Take(r.Context(), req.RunnerID, req.Labels, wait)
+	if errors.Is(err, reporting.ErrNoJob) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		// The job was assigned even if it couldn't be persisted
+		s.log.WithError(err).WithField("job_id", job.ID).Error("Failed to save job queue")
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"job_id":    job.ID,
+		"run_id":    job.RunID,
+		"runner_id": req.RunnerID,
+	}).Info("Job assigned")
+
+	s.writeJSON(w, http.StatusOK, Response{Data: reporting.JobAssignment{JobID: job.ID, RunID: job.RunID, Spec: job.Spec}})
+}
+
+// handleJobOperations serves /api/v1/jobs/{id} and /api/v1/jobs/{id}/complete
+func (s *Server) handleJobOperations(w http.ResponseWriter, r *http.Request) {
+	id, operation, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/v1/jobs/"), "/")
+
+	switch {
+	case operation == "" && r.Method == http.MethodGet:
+		s.readMiddleware(func(w http.ResponseWriter, _ *http.Request) { s.handleJobGet(w, id) })(w, r)
+	case operation == "complete" && r.Method == http.MethodPost:
+		s.authMiddleware(func(w http.ResponseWriter, r *http.Request) { s.handleJobComplete(w, r, id) })(w, r)
+	default:
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleJobGet(w http.ResponseWriter, id string) {
+	s.failOrphanedJobs()
+
+	job, err := s.jobs.Get(id)
+	if err != nil {
+		s.writeError(w, err, http.StatusNotFound)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, Response{Data: s.redactor.Job(*job)})
+}
+
+func (s *Server) handleJobComplete(w http.ResponseWriter, r *http.Request, id string) {
+	var req reporting.JobCompleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	job, err := s.jobs.Complete(id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrJobNotFound):
+			s.writeError(w, err, http.StatusNotFound)
+		case errors.Is(err, ErrJobNotRunning):
+			s.writeError(w, err, http.StatusConflict)
+		default:
+			s.writeError(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"job_id":    job.ID,
+		"run_id":    job.RunID,
+		"runner_id": job.RunnerID,
+		"status":    job.Status,
+	}).Info("Job finished")
+
+	s.writeJSON(w, http.StatusOK, Response{Data: s.redactor.Job(*job)})
+}
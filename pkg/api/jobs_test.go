@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobQueue(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	queue, err := LoadJobQueue(path)
+	require.NoError(t, err)
+
+	_, err = queue.Submit(reporting.JobSpec{Network: "hoodi"}, "ci")
+	require.ErrorIs(t, err, ErrInvalidJob)
+
+	arm, err := queue.Submit(reporting.JobSpec{
+		Network: "hoodi", ELClient: "geth", CLClient: "teku", RunnerLabels: map[string]string{"arch": "arm64"},
+	}, "ci")
+	require.NoError(t, err)
+	any, err := queue.Submit(reporting.JobSpec{Network: "hoodi", ELClient: "reth", CLClient: "lighthouse"}, "ci")
+	require.NoError(t, err)
+
+	// Runners only take jobs whose runner labels they have, oldest first
+	job, err := queue.Take(context.Background(), "box-1", map[string]string{"arch": "amd64"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, any.ID, job.ID)
+	assert.Equal(t, JobStatusRunning, job.Status)
+	assert.Contains(t, job.RunID, "hoodi_reth_lighthouse")
+
+	_, err = queue.Take(context.Background(), "box-1", map[string]string{"arch": "amd64"}, 0)
+	require.ErrorIs(t, err, reporting.ErrNoJob)
+
+	job, err = queue.Take(context.Background(), "box-2", map[string]string{"arch": "arm64"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, arm.ID, job.ID)
+
+	// Polls wait for jobs to be queued
+	taken := make(chan *Job)
+	go func() {
+		job, _ := queue.Take(context.Background(), "box-1", nil, time.Minute)
+		taken <- job
+	}()
+	time.Sleep(50 * time.Millisecond)
+	queued, err := queue.Submit(reporting.JobSpec{Network: "sepolia", ELClient: "besu", CLClient: "teku"}, "ci")
+	require.NoError(t, err)
+	select {
+	case job := <-taken:
+		require.NotNil(t, job)
+		assert.Equal(t, queued.ID, job.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("poll didn't return the queued job")
+	}
+
+	// Only the assigned runner completes a job
+	_, err = queue.Complete(any.ID, reporting.JobCompleteRequest{RunnerID: "box-2", Success: true})
+	require.ErrorIs(t, err, ErrJobNotRunning)
+	job, err = queue.Complete(any.ID, reporting.JobCompleteRequest{RunnerID: "box-1", Success: true})
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCompleted, job.Status)
+
+	failed := queue.FailOrphaned(func(runnerID string) bool { return runnerID == "box-2" })
+	assert.Equal(t, []string{arm.ID}, failed)
+
+	// Jobs survive a restart
+	reloaded, err := LoadJobQueue(path)
+	require.NoError(t, err)
+	jobs := reloaded.List()
+	require.Len(t, jobs, 3)
+	assert.Equal(t, queued.ID, jobs[0].ID)
+	assert.Equal(t, JobStatusRunning, jobs[0].Status)
+	assert.Equal(t, JobStatusCompleted, jobs[1].Status)
+	assert.Equal(t, JobStatusFailed, jobs[2].Status)
+	assert.Equal(t, "runner box-2 went offline", jobs[2].Error)
+}
+
+func TestJobQueueReleaseMissing(t *testing.T) {
+	t.Parallel()
+
+	queue := NewJobQueue()
+	lost, err := queue.Submit(reporting.JobSpec{Network: "hoodi", ELClient: "geth", CLClient: "teku"}, "ci")
+	require.NoError(t, err)
+	crashed, err := queue.Submit(reporting.JobSpec{Network: "hoodi", ELClient: "reth", CLClient: "teku"}, "ci")
+	require.NoError(t, err)
+	reported, err := queue.Submit(reporting.JobSpec{Network: "hoodi", ELClient: "besu", CLClient: "teku"}, "ci")
+	require.NoError(t, err)
+
+	runIDs := make(map[string]string)
+	for range 3 {
+		job, err := queue.Take(context.Background(), "box-1", nil, 0)
+		require.NoError(t, err)
+		runIDs[job.ID] = job.RunID
+	}
+	started := func(runID string) bool { return runID == runIDs[crashed.ID] }
+
+	// Runs get a grace period to show up in heartbeats
+	requeued, failed := queue.ReleaseMissing("box-1", nil, time.Hour, started)
+	assert.Empty(t, requeued)
+	assert.Empty(t, failed)
+
+	// Heartbeats of other runners don't release the jobs
+	requeued, failed = queue.ReleaseMissing("box-2", nil, 0, started)
+	assert.Empty(t, requeued)
+	assert.Empty(t, failed)
+
+	requeued, failed = queue.ReleaseMissing("box-1", []string{runIDs[reported.ID]}, 0, started)
+	assert.Equal(t, []string{lost.ID}, requeued)
+	assert.Equal(t, []string{crashed.ID}, failed)
+
+	job, err := queue.Get(lost.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusQueued, job.Status)
+	assert.Empty(t, job.RunnerID)
+	job, err = queue.Get(crashed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusFailed, job.Status)
+	job, err = queue.Get(reported.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusRunning, job.Status)
+
+	// The requeued job is taken again, with a new run ID
+	job, err = queue.Take(context.Background(), "box-2", nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, lost.ID, job.ID)
+	assert.NotEqual(t, runIDs[lost.ID], job.RunID)
+}
@@ -77,7 +77,7 @@ func (s *Server) createMockTest(runID, network, elType, clType, status string) {
 	testKeepAliveReq := s.buildMockTestRequest(runID, network, elType, clType)
 
 	// Create the test in the store
-	if err := s.store.CreateTest(testKeepAliveReq); err != nil {
+	if err := s.store.CreateTest(testKeepAliveReq, "mock"); err != nil {
 		s.log.WithFields(map[string]interface{}{
 			"run_id": runID,
 			"error":  err.Error(),
@@ -307,6 +307,7 @@ func (s *Server) addTimeoutTestData(runID, elType, clType string) {
 		FinalSlot:  62301000,                                // Lower final slot showing incomplete sync
 		Success:    false,                                   // Mark as failed due to timeout
 		Error:      "Sync operation timed out after 2h0m0s", // Mock timeout message
+		TimedOut:   true,
 	}
 
 	if err := s.store.CompleteTest(runID, timeoutReq); err != nil {
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Test statuses accepted by the status filter
+const (
+	TestStatusRunning   = "running"
+	TestStatusCompleted = "completed" // Completed successfully
+	TestStatusFailed    = "failed"    // Completed with an error
+	TestStatusCancelled = "cancelled" // Stopped after being cancelled through the API
+	TestStatusOrphaned  = "orphaned"  // Stopped sending keepalives
+)
+
+// testSortKeys extract the sortable value of a test, as a number or a string
+var testSortKeys = map[string]func(TestSummary) (int64, string){
+	"start_time":  func(t TestSummary) (int64, string) { return t.StartTime.UnixNano(), "" },
+	"last_update": func(t TestSummary) (int64, string) { return t.LastUpdate.UnixNano(), "" },
+	"run_id":      func(t TestSummary) (int64, string) { return 0, t.RunID },
+	"network":     func(t TestSummary) (int64, string) { return 0, t.Network },
+	"block": func(t TestSummary) (int64, string) {
+		if t.CurrentMetrics == nil {
+			return 0, ""
+		}
+		return int64(t.CurrentMetrics.Block), ""
+	},
+}
+
+// TestQuery filters, sorts and pages the test list. Zero values don't filter.
+type TestQuery struct {
+	Network  string
+	ELClient string
+	CLClient string
+	Labels   []string // "key=value" or "key" to require the label
+	Status   string
+	Since    time.Time // Start time lower bound
+	Until    time.Time // Start time upper bound
+
+	Sort       string
+	Descending bool
+	Limit      int // 0 returns all tests
+	Cursor     string
+
+	// Compact omits system info and client commands and environment variables
+	Compact bool
+}
+
+// testCursor is the position after the last test of a page
+type testCursor struct {
+	Number int64  `json:"n,omitempty"`
+	String string `json:"s,omitempty"`
+	RunID  string `json:"id"`
+}
+
+// parseTestQuery parses the query parameters of GET /api/v1/tests
+func parseTestQuery(values url.Values) (TestQuery, error) {
+	query := TestQuery{
+		Network:    values.Get("network"),
+		ELClient:   values.Get("el_client"),
+		CLClient:   values.Get("cl_client"),
+		Labels:     values["label"],
+		Status:     values.Get("status"),
+		Sort:       values.Get("sort"),
+		Descending: values.Get("order") != "asc",
+		Cursor:     values.Get("cursor"),
+		Compact:    values.Get("compact") == "true",
+	}
+
+	// active=true is kept for existing clients
+	if values.Get("active") == "true" {
+		query.Status = TestStatusRunning
+	}
+
+	switch query.Status {
+	case "", TestStatusRunning, TestStatusCompleted, TestStatusFailed, TestStatusCancelled, TestStatusOrphaned:
+	default:
+		return query, fmt.Errorf("%w: unknown status %q", ErrInvalidQuery, query.Status)
+	}
+
+	if query.Sort == "" {
+		query.Sort = "start_time"
+	}
+	if _, ok := testSortKeys[query.Sort]; !ok {
+		return query, fmt.Errorf("%w: unknown sort field %q", ErrInvalidQuery, query.Sort)
+	}
+	if order := values.Get("order"); order != "" && order != "asc" && order != "desc" {
+		return query, fmt.Errorf("%w: order must be asc or desc", ErrInvalidQuery)
+	}
+
+	var err error
+	if query.Since, err = parseQueryTime(values.Get("since")); err != nil {
+		return query, err
+	}
+	if query.Until, err = parseQueryTime(values.Get("until")); err != nil {
+		return query, err
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil || query.Limit < 0 {
+			return query, fmt.Errorf("%w: invalid limit %q", ErrInvalidQuery, limit)
+		}
+	}
+
+	return query, nil
+}
+
+// parseQueryTime parses an RFC 3339 time or unix seconds
+func parseQueryTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid time %q", ErrInvalidQuery, value)
+	}
+	return t, nil
+}
+
+// Apply returns a page of the matching tests, with counts over all matching tests and
+// the cursor of the next page, which is empty on the last page
+func (q TestQuery) Apply(tests []TestSummary) (TestListResponse, error) {
+	matched := make([]TestSummary, 0, len(tests))
+	for _, test := range tests {
+		if q.matches(test) {
+			matched = append(matched, test)
+		}
+	}
+
+	// Run IDs break ties so pages are stable
+	key := testSortKeys[q.Sort]
+	position := func(test TestSummary) testCursor {
+		number, str := key(test)
+		return testCursor{Number: number, String: str, RunID: test.RunID}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return q.before(position(matched[i]), position(matched[j]))
+	})
+
+	page := matched
+	if q.Cursor != "" {
+		cursor, err := decodeTestCursor(q.Cursor)
+		if err != nil {
+			return TestListResponse{}, err
+		}
+		start := sort.Search(len(matched), func(i int) bool { return q.before(cursor, position(matched[i])) })
+		page = matched[start:]
+	}
+
+	var next string
+	if q.Limit > 0 && len(page) > q.Limit {
+		page = page[:q.Limit]
+		next = encodeTestCursor(position(page[len(page)-1]))
+	}
+
+	if q.Compact {
+		for i := range page {
+			page[i] = compactTestSummary(page[i])
+		}
+	}
+
+	active := 0
+	for _, test := range matched {
+		if test.IsRunning {
+			active++
+		}
+	}
+
+	return TestListResponse{Tests: page, TotalCount: len(matched), ActiveCount: active, NextCursor: next}, nil
+}
+
+func (q TestQuery) matches(test TestSummary) bool {
+	switch {
+	case q.Network != "" && test.Network != q.Network,
+		q.ELClient != "" && test.ELClient != q.ELClient,
+		q.CLClient != "" && test.CLClient != q.CLClient,
+		q.Status != "" && testListStatus(test) != q.Status,
+		!q.Since.IsZero() && test.StartTime.Before(q.Since),
+		!q.Until.IsZero() && test.StartTime.After(q.Until):
+		return false
+	}
+
+	for _, selector := range q.Labels {
+		key, value, hasValue := strings.Cut(selector, "=")
+		actual, ok := test.Labels[key]
+		if !ok || (hasValue && actual != value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// before orders two positions by the sort direction, then by run ID
+func (q TestQuery) before(a, b testCursor) bool {
+	if a.Number != b.Number {
+		return (a.Number < b.Number) != q.Descending
+	}
+	if a.String != b.String {
+		return (a.String < b.String) != q.Descending
+	}
+	return a.RunID < b.RunID
+}
+
+// testListStatus returns the status filter value of a test
+func testListStatus(test TestSummary) string {
+	switch {
+	case test.IsRunning:
+		return TestStatusRunning
+	case test.IsComplete && test.Cancelled:
+		return TestStatusCancelled
+	case test.IsComplete && test.Error == "":
+		return TestStatusCompleted
+	case test.IsComplete:
+		return TestStatusFailed
+	default:
+		return TestStatusOrphaned
+	}
+}
+
+// status returns the status of a test as used by the status filter
+func (t *TestData) status() string {
+	return testListStatus(TestSummary{IsRunning: t.IsRunning, IsComplete: t.IsComplete, Cancelled: t.Cancelled, Error: t.Error})
+}
+
+// compactTestSummary drops the fields that make up most of a list payload
+func compactTestSummary(test TestSummary) TestSummary {
+	test.SystemInfo = nil
+	test.ELClientConfig.Cmd = nil
+	test.ELClientConfig.EnvVars = nil
+	test.CLClientConfig.Cmd = nil
+	test.CLClientConfig.EnvVars = nil
+	return test
+}
+
+func encodeTestCursor(cursor testCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeTestCursor(value string) (testCursor, error) {
+	var cursor testCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if err != nil {
+		return cursor, fmt.Errorf("%w: invalid cursor", ErrInvalidQuery)
+	}
+	return cursor, nil
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestQueryFiltersAndPages(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000, 0)
+	tests := []TestSummary{
+		{RunID: "a", Network: "hoodi", ELClient: "geth", StartTime: start, IsRunning: true, Labels: map[string]string{"team": "x"}},
+		{RunID: "b", Network: "hoodi", ELClient: "reth", StartTime: start.Add(time.Hour), IsComplete: true},
+		{RunID: "c", Network: "hoodi", ELClient: "geth", StartTime: start.Add(2 * time.Hour), IsComplete: true, Error: "failed"},
+		{RunID: "d", Network: "sepolia", ELClient: "geth", StartTime: start.Add(time.Hour),
+			ELClientConfig: reporting.ClientConfig{EnvVars: map[string]string{"K": "V"}}},
+	}
+
+	query, err := parseTestQuery(url.Values{"network": {"hoodi"}, "limit": {"2"}})
+	require.NoError(t, err)
+	first, err := query.Apply(tests)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, runIDs(first.Tests))
+	assert.Equal(t, 3, first.TotalCount)
+	assert.Equal(t, 1, first.ActiveCount)
+	require.NotEmpty(t, first.NextCursor)
+
+	query.Cursor = first.NextCursor
+	second, err := query.Apply(tests)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, runIDs(second.Tests))
+	assert.Empty(t, second.NextCursor)
+
+	for values, expected := range map[string][]string{
+		"status=failed":                        {"c"},
+		"status=orphaned":                      {"d"},
+		"label=team=x":                         {"a"},
+		"el_client=geth&sort=run_id&order=asc": {"a", "c", "d"},
+		"since=1000&until=4600":                {"b", "d", "a"},
+	} {
+		parsed, err := url.ParseQuery(values)
+		require.NoError(t, err)
+		query, err := parseTestQuery(parsed)
+		require.NoError(t, err)
+		response, err := query.Apply(tests)
+		require.NoError(t, err)
+		assert.Equal(t, expected, runIDs(response.Tests), values)
+	}
+
+	query, err = parseTestQuery(url.Values{"network": {"sepolia"}, "compact": {"true"}})
+	require.NoError(t, err)
+	compact, err := query.Apply(tests)
+	require.NoError(t, err)
+	assert.Nil(t, compact.Tests[0].ELClientConfig.EnvVars)
+	assert.NotNil(t, tests[3].ELClientConfig.EnvVars)
+
+	_, err = parseTestQuery(url.Values{"sort": {"bogus"}})
+	require.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func runIDs(tests []TestSummary) []string {
+	ids := make([]string, 0, len(tests))
+	for _, test := range tests {
+		ids = append(ids, test.RunID)
+	}
+	return ids
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+)
+
+// Report upload limits
+const (
+	maxReportUploadSize   = 512 << 20 // Total request size
+	maxReportUploadMemory = 32 << 20  // Kept in memory, the rest is buffered on disk
+)
+
+// ReportDetail is a report with its progress entries and index entry
+type ReportDetail struct {
+	Entry  report.IndexEntry `json:"entry"`
+	Report *report.Result    `json:"report"`
+}
+
+// handleReports serves the report index (GET) and accepts report uploads (POST)
+func (s *Server) handleReports(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.readMiddleware(s.handleReportIndex)(w, r)
+	case http.MethodPost:
+		s.authMiddleware(s.handleReportUpload)(w, r)
+	default:
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleReportIndex(w http.ResponseWriter, r *http.Request) {
+	if s.archive == nil {
+		s.writeError(w, fmt.Errorf("report storage is not configured"), http.StatusNotFound)
+		return
+	}
+
+	index, err := s.archive.currentIndex(r.Context())
+	if err != nil {
+		s.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, Response{Data: index})
+}
+
+// handleReportUpload stores a report uploaded as multipart "main" and "progress" files
+func (s *Server) handleReportUpload(w http.ResponseWriter, r *http.Request) {
+	if s.archive == nil {
+		s.writeError(w, fmt.Errorf("report storage is not configured"), http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxReportUploadSize)
+	if err := r.ParseMultipartForm(maxReportUploadMemory); err != nil {
+		s.writeError(w, fmt.Errorf("invalid multipart form: %w", err), http.StatusBadRequest)
+		return
+	}
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	mainData, err := readFormFile(r.MultipartForm, "main")
+	if err != nil {
+		s.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+	progressData, err := readFormFile(r.MultipartForm, "progress")
+	if err != nil {
+		s.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := report.ParseReport(mainData, progressData)
+	if err != nil {
+		s.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+	result.ExecutionClientInfo = s.redactor.ClientInfo(result.ExecutionClientInfo)
+	result.ConsensusClientInfo = s.redactor.ClientInfo(result.ConsensusClientInfo)
+
+	entry, err := s.archive.save(r.Context(), result)
+	if err != nil {
+		if errors.Is(err, ErrReportExists) {
+			s.writeError(w, err, http.StatusConflict)
+			return
+		}
+		s.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, Response{Data: entry})
+}
+
+// handleReportGet serves a report by run ID with its progress entries
+func (s *Server) handleReportGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.archive == nil {
+		s.writeError(w, fmt.Errorf("report storage is not configured"), http.StatusNotFound)
+		return
+	}
+
+	runID := strings.TrimPrefix(r.URL.Path, "/api/v1/reports/")
+	if runID == "" || strings.Contains(runID, "/") {
+		s.writeError(w, fmt.Errorf("invalid run ID"), http.StatusBadRequest)
+		return
+	}
+
+	index, err := s.archive.currentIndex(r.Context())
+	if err != nil {
+		s.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	entry := findIndexEntry(index, runID)
+	if entry == nil {
+		s.writeError(w, fmt.Errorf("report not found: %s", runID), http.StatusNotFound)
+		return
+	}
+
+	result, err := s.loadReport(r, entry)
+	if err != nil {
+		s.writeError(w, err, http.StatusInternalServerError)
+		return
+	}
+	// Reports written by runners directly to the storage were never redacted
+	result.ExecutionClientInfo = s.redactor.ClientInfo(result.ExecutionClientInfo)
+	result.ConsensusClientInfo = s.redactor.ClientInfo(result.ConsensusClientInfo)
+
+	s.writeJSON(w, http.StatusOK, Response{Data: ReportDetail{Entry: *entry, Report: result}})
+}
+
+// loadReport reads the main and progress file of an index entry. Stored reports are
+// decoded leniently since they may have been written by other syncoor versions.
+func (s *Server) loadReport(r *http.Request, entry *report.IndexEntry) (*report.Result, error) {
+	mainData, err := s.reportStorage.Read(r.Context(), entry.MainFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read main file: %w", err)
+	}
+
+	var result report.Result
+	if err := json.Unmarshal(mainData, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode main file: %w", err)
+	}
+
+	if entry.ProgressFile != "" {
+		progressData, err := s.reportStorage.Read(r.Context(), entry.ProgressFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read progress file: %w", err)
+		}
+		if err := json.Unmarshal(progressData, &result.SyncStatus.SyncProgress); err != nil {
+			return nil, fmt.Errorf("failed to decode progress file: %w", err)
+		}
+	}
+
+	return &result, nil
+}
+
+// readFormFile reads an uploaded file of a multipart form
+func readFormFile(form *multipart.Form, field string) ([]byte, error) {
+	files := form.File[field]
+	if len(files) != 1 {
+		return nil, fmt.Errorf("expected one %q file", field)
+	}
+
+	file, err := files[0].Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q file: %w", field, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q file: %w", field, err)
+	}
+	return data, nil
+}
+
+// handleReportFile serves a raw report file (main, progress or index) from the report storage
+func (s *Server) handleReportFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.reportStorage == nil {
+		s.writeError(w, fmt.Errorf("report storage is not configured"), http.StatusNotFound)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/api/v1/reports/files/")
+	if key == "" || path.Ext(key) != ".json" {
+		s.writeError(w, fmt.Errorf("invalid report file"), http.StatusBadRequest)
+		return
+	}
+
+	data, err := s.reportStorage.Read(r.Context(), key)
+	if err != nil {
+		switch {
+		case errors.Is(err, report.ErrObjectNotFound):
+			s.writeError(w, fmt.Errorf("report file not found"), http.StatusNotFound)
+		case errors.Is(err, report.ErrInvalidKey):
+			s.writeError(w, fmt.Errorf("invalid report file"), http.StatusBadRequest)
+		default:
+			s.writeError(w, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if strings.HasSuffix(key, ".main.json") {
+		if data, err = s.redactMainFile(data); err != nil {
+			s.writeError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		s.log.WithError(err).Error("Failed to write report file")
+	}
+}
+
+// redactMainFile masks secrets in the client information of a main report file,
+// leaving the other fields as stored
+func (s *Server) redactMainFile(data []byte) ([]byte, error) {
+	if s.redactor == nil {
+		return data, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode main file: %w", err)
+	}
+
+	for _, field := range []string{"execution_client_info", "consensus_client_info"} {
+		raw, exists := fields[field]
+		if !exists {
+			continue
+		}
+		var info report.ClientInfo
+		if err := json.Unmarshal(raw, &info); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", field, err)
+		}
+		redacted, err := json.Marshal(s.redactor.ClientInfo(info))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", field, err)
+		}
+		fields[field] = redacted
+	}
+
+	return json.Marshal(fields)
+}
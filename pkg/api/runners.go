@@ -0,0 +1,400 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/ethpandaops/syncoor/pkg/sysinfo"
+)
+
+var ErrRunnerNotFound = errors.New("runner not found")
+
+// Runner statuses
+const (
+	RunnerStatusIdle    = "idle"
+	RunnerStatusBusy    = "busy" // Running at least one test
+	RunnerStatusOffline = "offline"
+)
+
+// RunnerConfig configures when runners are considered offline and how long they are kept
+type RunnerConfig struct {
+	OfflineAfter time.Duration // Runners without a heartbeat for this long are offline
+	Retention    time.Duration // Offline runners are removed this long after their last heartbeat
+	MaxHistory   int           // Max runs kept per runner
+}
+
+// DefaultRunnerConfig returns the default runner settings of the server
+func DefaultRunnerConfig() RunnerConfig {
+	return RunnerConfig{
+		OfflineAfter: 3 * reporting.DefaultHeartbeatInterval,
+		Retention:    7 * 24 * time.Hour,
+		MaxHistory:   50,
+	}
+}
+
+// Runner is a machine running sync tests, identified by the runner ID it reports
+type Runner struct {
+	ID            string              `json:"id"`
+	Hostname      string              `json:"hostname"`
+	Labels        map[string]string   `json:"labels,omitempty"`
+	Capacity      int                 `json:"capacity"`
+	SystemInfo    *sysinfo.SystemInfo `json:"system_info,omitempty"`
+	RegisteredBy  string              `json:"registered_by,omitempty"` // Name of the API token of the last heartbeat
+	FirstSeen     time.Time           `json:"first_seen"`
+	LastHeartbeat time.Time           `json:"last_heartbeat"`
+	Status        string              `json:"status"`
+	Online        bool                `json:"online"`
+	CurrentRunIDs []string            `json:"current_run_ids"`
+	History       []RunnerRun         `json:"history"` // Most recent first
+
+	sessions map[string]*runnerSession
+}
+
+// runnerSession is a process sending heartbeats for a runner. Several processes may share
+// a runner ID, e.g. concurrent sync runs on one host.
+type runnerSession struct {
+	runIDs        []string
+	capacity      int
+	lastHeartbeat time.Time
+}
+
+// RunnerRun is a test executed by a runner
+type RunnerRun struct {
+	RunID     string     `json:"run_id"`
+	Network   string     `json:"network,omitempty"`
+	ELClient  string     `json:"el_client,omitempty"`
+	CLClient  string     `json:"cl_client,omitempty"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Status    string     `json:"status"` // One of the test statuses
+	Error     string     `json:"error,omitempty"`
+}
+
+type RunnerListResponse struct {
+	Runners     []Runner `json:"runners"`
+	TotalCount  int      `json:"total_count"`
+	OnlineCount int      `json:"online_count"`
+}
+
+// RunnerRegistry tracks runners from their heartbeats and the tests they report. It is
+// kept in memory, runners register again with their next heartbeat after a restart.
+type RunnerRegistry struct {
+	mu      sync.RWMutex
+	config  RunnerConfig
+	runners map[string]*Runner
+	created time.Time
+}
+
+func NewRunnerRegistry(config RunnerConfig) *RunnerRegistry {
+	return &RunnerRegistry{
+		config:  config,
+		runners: make(map[string]*Runner),
+		created: time.Now(),
+	}
+}
+
+// Heartbeat registers or updates a runner and returns the tests it is running. Run IDs
+// and capacity are merged across the sessions of the runner that are still sending
+// heartbeats. Runs no session reports as running without having completed are marked
+// orphaned.
+func (r *RunnerRegistry) Heartbeat(req reporting.RunnerHeartbeatRequest, registeredBy string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.prune(now)
+
+	runner := r.runner(req.RunnerID, now)
+	runner.Hostname = req.Hostname
+	runner.Labels = req.Labels
+	runner.RegisteredBy = registeredBy
+	runner.LastHeartbeat = now
+	if req.SystemInfo != nil {
+		runner.SystemInfo = req.SystemInfo
+	}
+
+	for _, runID := range req.RunIDs {
+		if r.findRun(runner, runID) == nil {
+			r.addRun(runner, RunnerRun{RunID: runID, StartTime: now})
+		}
+	}
+	runner.sessions[req.SessionID] = &runnerSession{runIDs: req.RunIDs, capacity: req.Capacity, lastHeartbeat: now}
+	r.mergeSessions(runner, now)
+
+	current := make(map[string]bool, len(runner.CurrentRunIDs))
+	for _, runID := range runner.CurrentRunIDs {
+		current[runID] = true
+	}
+	for i := range runner.History {
+		run := &runner.History[i]
+		switch {
+		case run.EndTime != nil:
+		case current[run.RunID]:
+			run.Status = TestStatusRunning
+		default:
+			run.Status = TestStatusOrphaned
+		}
+	}
+
+	return append([]string{}, runner.CurrentRunIDs...)
+}
+
+// mergeSessions drops sessions without a recent heartbeat and sets the current runs and
+// capacity of the runner from the others. Runs that completed are no longer current even
+// if their session didn't send another heartbeat yet. Must be called with mu held.
+func (r *RunnerRegistry) mergeSessions(runner *Runner, now time.Time) {
+	runner.CurrentRunIDs = []string{}
+	runner.Capacity = 0
+
+	seen := make(map[string]bool)
+	for id, session := range runner.sessions {
+		if now.Sub(session.lastHeartbeat) > r.config.OfflineAfter {
+			delete(runner.sessions, id)
+			continue
+		}
+
+		runner.Capacity += session.capacity
+		for _, runID := range session.runIDs {
+			if run := r.findRun(runner, runID); seen[runID] || (run != nil && run.EndTime != nil) {
+				continue
+			}
+			seen[runID] = true
+			runner.CurrentRunIDs = append(runner.CurrentRunIDs, runID)
+		}
+	}
+	sort.Strings(runner.CurrentRunIDs)
+}
+
+// StartRun records a test reported by a runner
+func (r *RunnerRegistry) StartRun(req reporting.TestKeepaliveRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	runner := r.runner(req.RunnerID, time.Now())
+	run := RunnerRun{
+		RunID:     req.RunID,
+		Network:   req.Network,
+		ELClient:  req.ELClient.Type,
+		CLClient:  req.CLClient.Type,
+		StartTime: time.Unix(req.Timestamp, 0),
+	}
+
+	// The run is known if a heartbeat reported it first
+	if existing := r.findRun(runner, req.RunID); existing != nil {
+		run.Status = existing.Status
+		*existing = run
+		return
+	}
+	r.addRun(runner, run)
+}
+
+// FinishRun records the result of a test of a runner
+func (r *RunnerRegistry) FinishRun(runnerID, runID string, req reporting.TestCompleteRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	runner, exists := r.runners[runnerID]
+	if !exists {
+		return
+	}
+	run := r.findRun(runner, runID)
+	if run == nil {
+		return
+	}
+
+	endTime := time.Unix(req.Timestamp, 0)
+	run.EndTime = &endTime
+	run.Error = req.Error
+	// As in the job queue and the archive, the result comes from the reported success, not the error
+	switch {
+	case req.Cancelled:
+		run.Status = TestStatusCancelled
+	case req.Success && !req.TimedOut:
+		run.Status = TestStatusCompleted
+	default:
+		run.Status = TestStatusFailed
+	}
+}
+
+// List returns all runners sorted by ID
+func (r *RunnerRegistry) List() RunnerListResponse {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	response := RunnerListResponse{Runners: make([]Runner, 0, len(r.runners))}
+	for _, runner := range r.runners {
+		snapshot := r.snapshot(runner, now)
+		if snapshot.Online {
+			response.OnlineCount++
+		}
+		response.Runners = append(response.Runners, snapshot)
+	}
+	sort.Slice(response.Runners, func(i, j int) bool { return response.Runners[i].ID < response.Runners[j].ID })
+	response.TotalCount = len(response.Runners)
+
+	return response
+}
+
+// Get returns a runner by ID
+func (r *RunnerRegistry) Get(id string) (*Runner, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	runner, exists := r.runners[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrRunnerNotFound, id)
+	}
+
+	snapshot := r.snapshot(runner, time.Now())
+	return &snapshot, nil
+}
+
+// Offline reports whether a runner stopped sending heartbeats. Unknown runners are
+// offline once they had time to register, e.g. after a server restart.
+func (r *RunnerRegistry) Offline(id string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	runner, exists := r.runners[id]
+	if !exists {
+		return now.Sub(r.created) > r.config.OfflineAfter
+	}
+	return !r.snapshot(runner, now).Online
+}
+
+// runner returns a runner, registering it if unknown. Must be called with mu held.
+func (r *RunnerRegistry) runner(id string, now time.Time) *Runner {
+	runner, exists := r.runners[id]
+	if !exists {
+		runner = &Runner{ID: id, FirstSeen: now, CurrentRunIDs: []string{}, sessions: make(map[string]*runnerSession)}
+		r.runners[id] = runner
+	}
+	return runner
+}
+
+// addRun appends a run, dropping the oldest ones beyond the history limit
+func (r *RunnerRegistry) addRun(runner *Runner, run RunnerRun) {
+	if run.Status == "" {
+		run.Status = TestStatusRunning
+	}
+	runner.History = append(runner.History, run)
+	if r.config.MaxHistory > 0 && len(runner.History) > r.config.MaxHistory {
+		runner.History = runner.History[len(runner.History)-r.config.MaxHistory:]
+	}
+}
+
+func (r *RunnerRegistry) findRun(runner *Runner, runID string) *RunnerRun {
+	for i := range runner.History {
+		if runner.History[i].RunID == runID {
+			return &runner.History[i]
+		}
+	}
+	return nil
+}
+
+// prune removes runners without a heartbeat within the retention. Must be called with mu held.
+func (r *RunnerRegistry) prune(now time.Time) {
+	for id, runner := range r.runners {
+		lastSeen := runner.LastHeartbeat
+		if lastSeen.IsZero() {
+			lastSeen = runner.FirstSeen
+		}
+		if now.Sub(lastSeen) > r.config.Retention {
+			delete(r.runners, id)
+		}
+	}
+}
+
+// snapshot copies a runner with its status as of now, history most recent first
+func (r *RunnerRegistry) snapshot(runner *Runner, now time.Time) Runner {
+	snapshot := *runner
+	snapshot.CurrentRunIDs = append([]string{}, runner.CurrentRunIDs...)
+	snapshot.History = make([]RunnerRun, len(runner.History))
+	for i, run := range runner.History {
+		snapshot.History[len(runner.History)-1-i] = run
+	}
+
+	snapshot.Online = !runner.LastHeartbeat.IsZero() && now.Sub(runner.LastHeartbeat) <= r.config.OfflineAfter
+	switch {
+	case !snapshot.Online:
+		snapshot.Status = RunnerStatusOffline
+		snapshot.CurrentRunIDs = []string{}
+	case len(runner.CurrentRunIDs) > 0:
+		snapshot.Status = RunnerStatusBusy
+	default:
+		snapshot.Status = RunnerStatusIdle
+	}
+
+	return snapshot
+}
+
+// SetRunnerRegistry replaces the default runner registry
+func (s *Server) SetRunnerRegistry(runners *RunnerRegistry) {
+	s.runners = runners
+}
+
+// handleRunners lists the registered runners
+func (s *Server) handleRunners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, Response{Data: s.runners.List()})
+}
+
+// handleRunnerGet serves /api/v1/runners/{id}
+func (s *Server) handleRunnerGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	runner, err := s.runners.Get(strings.TrimPrefix(r.URL.Path, "/api/v1/runners/"))
+	if err != nil {
+		s.writeError(w, err, http.StatusNotFound)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, Response{Data: runner})
+}
+
+// handleRunnerHeartbeat registers a runner and the tests it is running
+func (s *Server) handleRunnerHeartbeat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req reporting.RunnerHeartbeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	if req.RunnerID == "" || strings.Contains(req.RunnerID, "/") {
+		s.writeError(w, fmt.Errorf("invalid runner_id"), http.StatusBadRequest)
+		return
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"runner_id":  req.RunnerID,
+		"session_id": req.SessionID,
+		"hostname":   req.Hostname,
+		"run_ids":    req.RunIDs,
+	}).Debug("Runner heartbeat received")
+
+	runIDs := s.runners.Heartbeat(req, tokenName(r))
+	s.releaseMissingJobs(req.RunnerID, runIDs)
+
+	s.writeJSON(w, http.StatusOK, Response{Data: map[string]string{"status": "acknowledged"}})
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRunnerRegistry(DefaultRunnerConfig())
+	now := time.Now().Unix()
+
+	registry.Heartbeat(reporting.RunnerHeartbeatRequest{RunnerID: "box-1", Hostname: "box-1", Capacity: 1, RunIDs: []string{}}, "runner")
+	registry.Heartbeat(reporting.RunnerHeartbeatRequest{RunnerID: "box-2", Hostname: "box-2", Capacity: 1, RunIDs: []string{}}, "runner")
+
+	// A test reported before the next heartbeat
+	registry.StartRun(reporting.TestKeepaliveRequest{
+		RunID:     "run-1",
+		RunnerID:  "box-1",
+		Timestamp: now,
+		Network:   "hoodi",
+		ELClient:  reporting.ClientConfig{Type: "geth"},
+		CLClient:  reporting.ClientConfig{Type: "teku"},
+	})
+	registry.Heartbeat(reporting.RunnerHeartbeatRequest{RunnerID: "box-1", Hostname: "box-1", Capacity: 1, RunIDs: []string{"run-1"}}, "runner")
+
+	// A test the runner stopped reporting without completing it
+	registry.Heartbeat(reporting.RunnerHeartbeatRequest{RunnerID: "box-2", Capacity: 1, RunIDs: []string{"run-2"}}, "runner")
+	registry.Heartbeat(reporting.RunnerHeartbeatRequest{RunnerID: "box-2", Capacity: 1, RunIDs: []string{}}, "runner")
+
+	list := registry.List()
+	require.Len(t, list.Runners, 2)
+	assert.Equal(t, 2, list.OnlineCount)
+	assert.Equal(t, RunnerStatusBusy, list.Runners[0].Status)
+	assert.Equal(t, []string{"run-1"}, list.Runners[0].CurrentRunIDs)
+	assert.Equal(t, "geth", list.Runners[0].History[0].ELClient)
+	assert.Equal(t, TestStatusRunning, list.Runners[0].History[0].Status)
+	assert.Equal(t, RunnerStatusIdle, list.Runners[1].Status)
+	assert.Equal(t, TestStatusOrphaned, list.Runners[1].History[0].Status)
+
+	registry.FinishRun("box-1", "run-1", reporting.TestCompleteRequest{Timestamp: now + 60, Success: true, TimedOut: true})
+	registry.Heartbeat(reporting.RunnerHeartbeatRequest{RunnerID: "box-1", Capacity: 1, RunIDs: []string{}}, "runner")
+
+	runner, err := registry.Get("box-1")
+	require.NoError(t, err)
+	assert.Equal(t, RunnerStatusIdle, runner.Status)
+	assert.Equal(t, TestStatusFailed, runner.History[0].Status)
+	require.NotNil(t, runner.History[0].EndTime)
+
+	// Runners without heartbeats go offline
+	registry.mu.Lock()
+	registry.runners["box-2"].LastHeartbeat = time.Now().Add(-time.Hour)
+	registry.mu.Unlock()
+
+	runner, err = registry.Get("box-2")
+	require.NoError(t, err)
+	assert.Equal(t, RunnerStatusOffline, runner.Status)
+	assert.False(t, runner.Online)
+
+	_, err = registry.Get("box-3")
+	assert.ErrorIs(t, err, ErrRunnerNotFound)
+
+	// Processes sharing a runner ID keep each other's runs
+	heartbeat := func(session string, runIDs ...string) []string {
+		return registry.Heartbeat(reporting.RunnerHeartbeatRequest{RunnerID: "box-3", SessionID: session, Capacity: 1, RunIDs: runIDs}, "runner")
+	}
+	heartbeat("a", "run-3")
+	assert.Equal(t, []string{"run-3", "run-4"}, heartbeat("b", "run-4"))
+	assert.Equal(t, []string{"run-3", "run-4"}, heartbeat("a", "run-3"))
+
+	runner, err = registry.Get("box-3")
+	require.NoError(t, err)
+	assert.Equal(t, 2, runner.Capacity)
+	assert.Equal(t, TestStatusRunning, runner.History[0].Status)
+
+	// Completed runs and sessions without heartbeats no longer count
+	registry.FinishRun("box-3", "run-3", reporting.TestCompleteRequest{Timestamp: now, Success: true})
+	registry.mu.Lock()
+	registry.runners["box-3"].sessions["b"].lastHeartbeat = time.Now().Add(-time.Hour)
+	registry.mu.Unlock()
+	assert.Empty(t, heartbeat("a"))
+
+	runner, err = registry.Get("box-3")
+	require.NoError(t, err)
+	assert.Equal(t, 1, runner.Capacity)
+	assert.Equal(t, TestStatusOrphaned, runner.History[0].Status)
+	assert.Equal(t, TestStatusCompleted, runner.History[1].Status)
+}
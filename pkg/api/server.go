@@ -11,8 +11,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/ethpandaops/syncoor/pkg/report"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/r3labs/sse/v2"
 	"github.com/sirupsen/logrus"
 )
 
@@ -20,47 +20,74 @@ type Server struct {
 	log         logrus.FieldLogger
 	httpServer  *http.Server
 	router      *http.ServeMux
-	sseServer   *sse.Server
-	store       *Store
+	events      *eventHub
+	store       Store
+	runners     *RunnerRegistry
+	jobs        *JobQueue
 	authToken   string
+	tokens      *TokenStore
+	readAuth    bool
+	redactor    *Redactor
 	mockMode    bool
 	corsOrigins string
 
+	// reportStorage serves finished report files when configured
+	reportStorage report.Storage
+
+	// archive writes reports to the report storage, including completed tests
+	// when archiveCompleted is set
+	archive          *reportArchive
+	archiveCompleted bool
+
 	shutdownOnce sync.Once
 }
 
 func NewServer(log logrus.FieldLogger, addr string, authToken string) *Server {
-	store := NewStore(log)
+	store := NewMemoryStore(log, DefaultStoreConfig())
+
+	// The default patterns are known to compile
+	redactor, _ := NewRedactor(DefaultRedactPatterns)
 
 	s := &Server{
 		log:         log,
 		store:       store,
+		runners:     NewRunnerRegistry(DefaultRunnerConfig()),
+		jobs:        NewJobQueue(),
 		authToken:   authToken,
+		tokens:      NewTokenStore(),
+		redactor:    redactor,
 		router:      http.NewServeMux(),
-		sseServer:   sse.New(),
+		events:      newEventHub(),
 		corsOrigins: "*",
 	}
 
+	if authToken != "" {
+		// A single non-empty token can't conflict with an empty store
+		_ = s.tokens.AddLegacyToken(authToken)
+	}
+
 	s.httpServer = &http.Server{
 		Addr:    addr,
 		Handler: s.router,
 	}
+	s.httpServer.RegisterOnShutdown(func() { s.jobs.Close() })
+	// Event streams never go idle, they must end for the shutdown to complete
+	s.httpServer.RegisterOnShutdown(s.events.close)
 
 	s.setupRoutes()
-	s.setupSSE()
 	s.setupMetrics()
 
 	return s
 }
 
 func (s *Server) Start(ctx context.Context) error {
+	s.store.SetOrphanedHandler(s.handleTestsOrphaned)
 	s.store.Start()
 
 	s.log.WithFields(map[string]interface{}{
-		"addr":              s.httpServer.Addr,
-		"auth_enabled":      s.authToken != "",
-		"store_max_age":     "24h",
-		"store_max_history": 1000,
+		"addr":         s.httpServer.Addr,
+		"auth_enabled": s.tokens.Enabled(),
+		"store":        s.store.String(),
 	}).Info("Starting syncoor server")
 
 	// Setup graceful shutdown
@@ -103,11 +130,16 @@ func (s *Server) Stop(ctx context.Context) error {
 			err = fmt.Errorf("failed to shutdown HTTP server: %w", shutdownErr)
 		}
 
+		// Finish archiving completed tests before the store is closed
+		if s.archive != nil {
+			s.archive.wait()
+		}
+
 		// Stop store
 		s.store.Stop()
 
-		// Close SSE server
-		s.sseServer.Close()
+		// Close event streams
+		s.events.close()
 
 		s.log.Info("Server stopped")
 	})
@@ -126,20 +158,67 @@ func (s *Server) SetCORSOrigins(origins string) {
 	s.corsOrigins = origins
 }
 
+// SetStore replaces the default in-memory store, it must be called before Start
+func (s *Server) SetStore(store Store) {
+	s.store = store
+}
+
+// SetTokenStore replaces the tokens accepted by the server. The auth token passed to
+// NewServer is added to the store with all scopes.
+func (s *Server) SetTokenStore(tokens *TokenStore) error {
+	if s.authToken != "" {
+		if err := tokens.AddLegacyToken(s.authToken); err != nil {
+			return err
+		}
+	}
+	s.tokens = tokens
+	return nil
+}
+
+// EnableReadAuth requires a token with the read scope for test, report and event
+// endpoints. Tokens must be configured.
+func (s *Server) EnableReadAuth() error {
+	if !s.tokens.Enabled() {
+		return ErrReadAuthRequiresTokens
+	}
+	s.readAuth = true
+	return nil
+}
+
+// SetRedactor replaces the default redaction of client configurations, nil disables it
+func (s *Server) SetRedactor(redactor *Redactor) {
+	s.redactor = redactor
+}
+
+// SetReportStorage sets the backend report files are served from
+func (s *Server) SetReportStorage(storage report.Storage) {
+	s.reportStorage = storage
+	s.archive = newReportArchive(s.log, storage)
+}
+
 // Setup methods
 func (s *Server) setupRoutes() {
 	// Client endpoints (require auth)
 	s.router.HandleFunc("/api/v1/tests/keepalive", s.corsMiddleware(s.authMiddleware(s.handleTestKeepalive)))
-	s.router.HandleFunc("/api/v1/tests/", s.corsMiddleware(s.authMiddleware(s.handleTestOperations)))
-
-	// Public endpoints (no auth)
-	s.router.HandleFunc("/api/v1/tests", s.corsMiddleware(s.handleTestList))
-	s.router.Handle("/api/v1/events", s.corsHandlerWrapper(s.sseServer))
+	s.router.HandleFunc("/api/v1/tests/", s.corsMiddleware(s.handleTestOperations))
+	s.router.HandleFunc("/api/v1/runners/heartbeat", s.corsMiddleware(s.authMiddleware(s.handleRunnerHeartbeat)))
+	s.router.HandleFunc("/api/v1/jobs/poll", s.corsMiddleware(s.authMiddleware(s.handleJobPoll)))
+	s.router.HandleFunc("/api/v1/jobs", s.corsMiddleware(s.handleJobs))
+	s.router.HandleFunc("/api/v1/jobs/", s.corsMiddleware(s.handleJobOperations))
+
+	// Public endpoints (read auth if enabled)
+	s.router.HandleFunc("/api/v1/tests", s.corsMiddleware(s.readMiddleware(s.handleTestList)))
+	s.router.HandleFunc("/api/v1/runners", s.corsMiddleware(s.readMiddleware(s.handleRunners)))
+	s.router.HandleFunc("/api/v1/runners/", s.corsMiddleware(s.readMiddleware(s.handleRunnerGet)))
+	s.router.HandleFunc("/api/v1/reports", s.corsMiddleware(s.handleReports))
+	s.router.HandleFunc("/api/v1/reports/", s.corsMiddleware(s.readMiddleware(s.handleReportGet)))
+	s.router.HandleFunc("/api/v1/reports/files/", s.corsMiddleware(s.readMiddleware(s.handleReportFile)))
+	s.router.HandleFunc("/api/v1/events", s.corsMiddleware(s.readMiddleware(s.handleEvents)))
 	s.router.HandleFunc("/health", s.corsMiddleware(s.handleHealth))
-}
 
-func (s *Server) setupSSE() {
-	s.sseServer.CreateStream("tests")
+	// Admin endpoints
+	s.router.HandleFunc("/api/v1/admin/tokens", s.corsMiddleware(s.requireScope(ScopeAdmin, s.handleTokens)))
+	s.router.HandleFunc("/api/v1/admin/tokens/", s.corsMiddleware(s.requireScope(ScopeAdmin, s.handleTokenRevoke)))
 }
 
 func (s *Server) setupMetrics() {
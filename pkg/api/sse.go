@@ -2,62 +2,328 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethpandaops/syncoor/pkg/reporting"
-	"github.com/r3labs/sse/v2"
 )
 
-func (s *Server) setupSSEStreams() {
-	s.sseServer.CreateStream("tests")
+// SSE event types, sent as the event field and the type of the event data
+const (
+	EventTestStart     = "test_start"
+	EventTestKeepalive = "test_keepalive"
+	EventTestProgress  = "test_progress"
+	EventTestCancel    = "test_cancel"
+	EventTestOrphaned  = "test_orphaned"
+	EventTestComplete  = "test_complete"
+	EventTestStatus    = "test_status" // The status of a test changed, sent after start, orphaned and complete events
+)
+
+const (
+	// sseHeartbeatInterval is how often a comment is sent, so proxies don't drop idle streams
+	sseHeartbeatInterval = 15 * time.Second
+	// sseHistorySize is the number of recent events kept for Last-Event-ID replay
+	sseHistorySize = 1000
+	// sseSubscriberBuffer is the number of events queued per subscriber. Subscribers
+	// falling further behind are disconnected and resume with Last-Event-ID.
+	sseSubscriberBuffer = 256
+)
+
+// EventFilter selects the events of a subscription, empty fields match all events
+type EventFilter struct {
+	RunID    string
+	Network  string
+	RunnerID string
+	Types    []string
 }
 
-func (s *Server) publishTestStart(runID string, test *TestData) {
-	event := SSEEvent{
-		Type:      "test_start",
-		RunID:     runID,
-		Timestamp: time.Now(),
-		Data: map[string]interface{}{
-			"network":   test.Network,
-			"el_client": test.ELClient.Type,
-			"cl_client": test.CLClient.Type,
-			"labels":    test.Labels,
-		},
+// parseEventFilter reads a filter from the run_id, network, runner_id and type
+// (comma separated) query parameters
+func parseEventFilter(values url.Values) EventFilter {
+	filter := EventFilter{
+		RunID:    values.Get("run_id"),
+		Network:  values.Get("network"),
+		RunnerID: values.Get("runner_id"),
+	}
+	for _, eventType := range strings.Split(values.Get("type"), ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			filter.Types = append(filter.Types, eventType)
+		}
 	}
-	s.publishEvent(event)
+	return filter
 }
 
-func (s *Server) publishTestProgress(runID string, metrics *reporting.ProgressMetrics) {
-	event := SSEEvent{
-		Type:      "test_progress",
-		RunID:     runID,
-		Timestamp: time.Now(),
-		Data:      metrics,
+func (f EventFilter) matches(event SSEEvent) bool {
+	return (f.RunID == "" || f.RunID == event.RunID) &&
+		(f.Network == "" || f.Network == event.Network) &&
+		(f.RunnerID == "" || f.RunnerID == event.RunnerID) &&
+		(len(f.Types) == 0 || slices.Contains(f.Types, event.Type))
+}
+
+// eventHub fans out events to subscribers and keeps recent events for replay
+type eventHub struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []SSEEvent
+	subscribers map[*eventSubscriber]struct{}
+	closed      bool
+}
+
+type eventSubscriber struct {
+	filter EventFilter
+	events chan SSEEvent // Closed when the subscriber falls behind or the hub closes
+}
+
+func newEventHub() *eventHub {
+	// IDs continue from the current time, so clients resuming after a server
+	// restart don't skip the events of the new server
+	return &eventHub{
+		lastID:      uint64(time.Now().UnixMicro()),
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+// publish assigns the next ID to an event and sends it to the matching subscribers
+func (h *eventHub) publish(event SSEEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.lastID++
+	event.ID = h.lastID
+
+	// Trim in batches rather than on every event
+	h.history = append(h.history, event)
+	if len(h.history) >= 2*sseHistorySize {
+		h.history = slices.Clone(h.history[len(h.history)-sseHistorySize:])
+	}
+
+	for sub := range h.subscribers {
+		if !sub.filter.matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			close(sub.events)
+			delete(h.subscribers, sub)
+		}
+	}
+}
+
+// subscribe adds a subscriber, returning the matching events after lastID to send
+// first. No events are replayed for a lastID of 0.
+func (h *eventHub) subscribe(filter EventFilter, lastID uint64) (*eventSubscriber, []SSEEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &eventSubscriber{filter: filter, events: make(chan SSEEvent, sseSubscriberBuffer)}
+	if h.closed {
+		close(sub.events)
+		return sub, nil
+	}
+	h.subscribers[sub] = struct{}{}
+
+	var replay []SSEEvent
+	if lastID > 0 {
+		for _, event := range h.history {
+			if event.ID > lastID && filter.matches(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	return sub, replay
+}
+
+func (h *eventHub) unsubscribe(sub *eventSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, exists := h.subscribers[sub]; exists {
+		delete(h.subscribers, sub)
+		close(sub.events)
 	}
-	s.publishEvent(event)
 }
 
-func (s *Server) publishTestComplete(runID string, success bool, error string) {
+// close disconnects all subscribers, events published afterwards are dropped
+func (h *eventHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// handleEvents streams test events. Subscriptions are narrowed with the run_id, network,
+// runner_id and type query parameters. Clients resuming with the Last-Event-ID header,
+// or the last_event_id parameter, first get the events they missed.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, fmt.Errorf("method not allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeError(w, fmt.Errorf("streaming unsupported"), http.StatusInternalServerError)
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	var lastID uint64
+	if lastEventID != "" {
+		var err error
+		if lastID, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			s.writeError(w, fmt.Errorf("invalid last event ID: %w", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	sub, replay := s.events.subscribe(parseEventFilter(r.URL.Query()), lastID)
+	defer s.events.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable buffering in nginx
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range replay {
+		if err := writeSSEEvent(w, event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.events:
+			if !ok {
+				return
+			}
+			if err := writeSSEEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSEEvent writes an unnamed message event, so EventSource.onmessage receives every
+// event. The event type is part of the data.
+func writeSSEEvent(w io.Writer, event SSEEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.ID, data)
+	return err
+}
+
+// publishTestEvent publishes an event of a test, tagged with its network and runner for filtering
+func (s *Server) publishTestEvent(eventType, runID string, data interface{}) {
 	event := SSEEvent{
-		Type:      "test_complete",
+		Type:      eventType,
 		RunID:     runID,
 		Timestamp: time.Now(),
-		Data: map[string]interface{}{
-			"success": success,
-			"error":   error,
-		},
+		Data:      data,
 	}
-	s.publishEvent(event)
+	if test, err := s.store.GetTest(runID); err == nil {
+		event.Network = test.Network
+		event.RunnerID = test.RunnerID
+	}
+	s.events.publish(event)
 }
 
-func (s *Server) publishEvent(event SSEEvent) {
-	data, err := json.Marshal(event)
-	if err != nil {
-		s.log.WithError(err).Error("Failed to marshal SSE event")
+// publishTestStatus publishes a status change of a test
+func (s *Server) publishTestStatus(runID, previous, status string) {
+	if previous == status {
 		return
 	}
+	s.publishTestEvent(EventTestStatus, runID, map[string]interface{}{
+		"status":          status,
+		"previous_status": previous,
+	})
+}
 
-	s.sseServer.Publish("tests", &sse.Event{
-		Data: data,
+func (s *Server) publishTestStart(test *TestData) {
+	s.publishTestEvent(EventTestStart, test.RunID, map[string]interface{}{
+		"network":   test.Network,
+		"el_client": test.ELClient.Type,
+		"cl_client": test.CLClient.Type,
+		"labels":    test.Labels,
+		"runner_id": test.RunnerID,
 	})
+	s.publishTestStatus(test.RunID, "", test.status())
+}
+
+func (s *Server) publishTestKeepalive(runID string, lastUpdate time.Time) {
+	s.publishTestEvent(EventTestKeepalive, runID, map[string]interface{}{
+		"last_update": lastUpdate,
+	})
+}
+
+func (s *Server) publishTestProgress(runID string, metrics *reporting.ProgressMetrics) {
+	s.publishTestEvent(EventTestProgress, runID, metrics)
+}
+
+func (s *Server) publishTestCancel(runID, reason string) {
+	s.publishTestEvent(EventTestCancel, runID, map[string]interface{}{
+		"reason": reason,
+	})
+}
+
+func (s *Server) publishTestComplete(runID, previous string, req reporting.TestCompleteRequest) {
+	s.publishTestEvent(EventTestComplete, runID, map[string]interface{}{
+		"success":     req.Success,
+		"cancelled":   req.Cancelled,
+		"error":       req.Error,
+		"final_block": req.FinalBlock,
+		"final_slot":  req.FinalSlot,
+	})
+	if test, err := s.store.GetTest(runID); err == nil {
+		s.publishTestStatus(runID, previous, test.status())
+	}
+}
+
+// publishTestsOrphaned is called by the store when tests stopped sending keepalives
+func (s *Server) publishTestsOrphaned(runIDs []string) {
+	for _, runID := range runIDs {
+		test, err := s.store.GetTest(runID)
+		if err != nil {
+			continue
+		}
+		s.publishTestEvent(EventTestOrphaned, runID, map[string]interface{}{
+			"last_update": test.LastUpdate,
+			"error":       test.Error,
+		})
+		s.publishTestStatus(runID, TestStatusRunning, test.status())
+	}
 }
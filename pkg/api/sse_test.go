@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHub(t *testing.T) {
+	t.Parallel()
+
+	hub := newEventHub()
+	hub.publish(SSEEvent{Type: EventTestStart, RunID: "run-1", Network: "hoodi"})
+	hub.publish(SSEEvent{Type: EventTestStart, RunID: "run-2", Network: "sepolia"})
+	first := hub.lastID - 1
+
+	// Subscribers resuming after an event get the matching events they missed
+	filter := parseEventFilter(url.Values{"network": {"sepolia"}, "type": {"test_start, test_complete"}})
+	sub, replay := hub.subscribe(filter, first-1)
+	require.Len(t, replay, 1)
+	assert.Equal(t, "run-2", replay[0].RunID)
+
+	_, replay = hub.subscribe(EventFilter{}, 0)
+	assert.Empty(t, replay)
+
+	hub.publish(SSEEvent{Type: EventTestProgress, RunID: "run-2", Network: "sepolia"})
+	hub.publish(SSEEvent{Type: EventTestComplete, RunID: "run-1", Network: "hoodi"})
+	hub.publish(SSEEvent{Type: EventTestComplete, RunID: "run-2", Network: "sepolia"})
+	event := <-sub.events
+	assert.Equal(t, EventTestComplete, event.Type)
+	assert.Equal(t, "run-2", event.RunID)
+	assert.Equal(t, first+4, event.ID)
+
+	// Subscribers falling behind are disconnected
+	slow, _ := hub.subscribe(EventFilter{RunID: "run-3"}, 0)
+	for i := 0; i <= sseSubscriberBuffer; i++ {
+		hub.publish(SSEEvent{Type: EventTestProgress, RunID: "run-3"})
+	}
+	for range slow.events {
+	}
+
+	hub.close()
+	_, open := <-sub.events
+	assert.False(t, open)
+}
+
+func TestEventStream(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(logrus.New(), "", "")
+	httpServer := httptest.NewServer(server.router)
+	defer httpServer.Close()
+	defer server.events.close()
+
+	server.events.publish(SSEEvent{Type: EventTestStart, RunID: "run-1"})
+	server.events.publish(SSEEvent{Type: EventTestStart, RunID: "run-2"})
+	lastID := server.events.lastID
+
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/api/v1/events?run_id=run-2", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(lastID-2, 10))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() []string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if line == "\n" {
+				return lines
+			}
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+	}
+
+	lines := readEvent()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "id: "+strconv.FormatUint(lastID, 10), lines[0])
+	assert.Contains(t, lines[1], `"type":"test_start"`)
+	assert.Contains(t, lines[1], `"run_id":"run-2"`)
+
+	server.events.publish(SSEEvent{Type: EventTestProgress, RunID: "run-1"})
+	server.events.publish(SSEEvent{Type: EventTestProgress, RunID: "run-2"})
+	lines = readEvent()
+	assert.Contains(t, lines[1], `"type":"test_progress"`)
+	assert.Contains(t, lines[1], `"run_id":"run-2"`)
+}
@@ -15,65 +15,132 @@ var (
 	ErrTestAlreadyExists = errors.New("test already exists")
 	ErrTestNotFound      = errors.New("test not found")
 	ErrTestComplete      = errors.New("test is already complete")
+	ErrTestNotRunning    = errors.New("test is not running")
 )
 
-type Store struct {
+// Store holds the state of the tests reported to the server
+type Store interface {
+	Start()
+	Stop()
+
+	CreateTest(req reporting.TestKeepaliveRequest, createdBy string) error
+	UpdateProgress(runID string, metrics reporting.ProgressMetrics) error
+	UpdateTestKeepalive(req reporting.TestKeepaliveRequest) error
+	CompleteTest(runID string, req reporting.TestCompleteRequest) error
+	// RequestCancel flags a running test as cancelled, its runner stops it on its next update
+	RequestCancel(runID, reason, requestedBy string) error
+
+	// SetOrphanedHandler sets a function called with the tests marked orphaned, it must be called before Start
+	SetOrphanedHandler(handler func(runIDs []string))
+
+	GetTest(runID string) (*TestData, error)
+	ListTests(activeOnly bool) []TestSummary
+	GetTestDetail(runID string) (*TestDetail, error)
+
+	// String describes the store for logging
+	String() string
+}
+
+// StoreConfig configures how long tests are kept
+type StoreConfig struct {
+	MaxAge            time.Duration // Tests are removed this long after their last update
+	FinishedRetention time.Duration // Finished and orphaned tests are removed this long after their last update
+	MaxHistory        int           // Max progress points per test
+}
+
+// DefaultStoreConfig returns the default retention of the server
+func DefaultStoreConfig() StoreConfig {
+	return StoreConfig{
+		MaxAge:            24 * time.Hour,
+		FinishedRetention: 20 * time.Minute,
+		MaxHistory:        1000,
+	}
+}
+
+// MemoryStore keeps tests in memory only, they are lost when the server restarts
+type MemoryStore struct {
 	log   logrus.FieldLogger
 	mu    sync.RWMutex
 	tests map[string]*TestData
 
+	// version is incremented on every change of tests
+	version uint64
+
+	onOrphaned func(runIDs []string)
+
 	// Cleanup configuration
-	maxAge      time.Duration
-	maxHistory  int // Max progress points per test
+	config      StoreConfig
 	cleanupTick *time.Ticker
 	stopCh      chan struct{}
 }
 
 type TestData struct {
-	RunID      string
-	Network    string
-	Labels     map[string]string
-	StartTime  time.Time
-	LastUpdate time.Time
-	EndTime    *time.Time
-	IsRunning  bool
-	IsComplete bool
-	Error      string
-
-	ELClient    reporting.ClientConfig
-	CLClient    reporting.ClientConfig
-	EnclaveName string
-	SystemInfo  *sysinfo.SystemInfo
-	RunTimeout  int64
-
-	CurrentMetrics *reporting.ProgressMetrics
-	History        []ProgressPoint
+	RunID      string            `json:"run_id"`
+	Network    string            `json:"network"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	StartTime  time.Time         `json:"start_time"`
+	LastUpdate time.Time         `json:"last_update"`
+	EndTime    *time.Time        `json:"end_time,omitempty"`
+	IsRunning  bool              `json:"is_running"`
+	IsComplete bool              `json:"is_complete"`
+	Cancelled  bool              `json:"cancelled,omitempty"` // Completed after being cancelled
+	Error      string            `json:"error,omitempty"`
+
+	// Reported by the runner when the test completes
+	Success    bool   `json:"success,omitempty"`
+	TimedOut   bool   `json:"timed_out,omitempty"`
+	FinalBlock uint64 `json:"final_block,omitempty"`
+	FinalSlot  uint64 `json:"final_slot,omitempty"`
+
+	// Set when the test is cancelled through the API
+	CancelRequestedAt *time.Time `json:"cancel_requested_at,omitempty"`
+	CancelRequestedBy string     `json:"cancel_requested_by,omitempty"` // Name of the API token that cancelled the test
+	CancelReason      string     `json:"cancel_reason,omitempty"`
+
+	ELClient    reporting.ClientConfig `json:"el_client"`
+	CLClient    reporting.ClientConfig `json:"cl_client"`
+	EnclaveName string                 `json:"enclave_name"`
+	SystemInfo  *sysinfo.SystemInfo    `json:"system_info,omitempty"`
+	RunTimeout  int64                  `json:"run_timeout,omitempty"`
+	CreatedBy   string                 `json:"created_by,omitempty"` // Name of the API token that created the test
+	RunnerID    string                 `json:"runner_id,omitempty"`
+
+	CurrentMetrics *reporting.ProgressMetrics `json:"current_metrics,omitempty"`
+	History        []ProgressPoint            `json:"history"`
+	HistoryDropped int                        `json:"history_dropped,omitempty"` // Oldest points dropped beyond MaxHistory
 }
 
-func NewStore(log logrus.FieldLogger) *Store {
-	return &Store{
-		log:        log,
-		tests:      make(map[string]*TestData),
-		maxAge:     24 * time.Hour,
-		maxHistory: 1000,
-		stopCh:     make(chan struct{}),
+func NewMemoryStore(log logrus.FieldLogger, config StoreConfig) *MemoryStore {
+	return &MemoryStore{
+		log:    log,
+		tests:  make(map[string]*TestData),
+		config: config,
+		stopCh: make(chan struct{}),
 	}
 }
 
-func (s *Store) Start() {
+func (s *MemoryStore) String() string {
+	return fmt.Sprintf("memory (max age %s, finished retention %s)", s.config.MaxAge, s.config.FinishedRetention)
+}
+
+func (s *MemoryStore) Start() {
 	s.cleanupTick = time.NewTicker(20 * time.Minute)
 	go s.cleanupLoop()
 }
 
-func (s *Store) Stop() {
+func (s *MemoryStore) Stop() {
 	if s.cleanupTick != nil {
 		s.cleanupTick.Stop()
 	}
 	close(s.stopCh)
 }
 
+func (s *MemoryStore) SetOrphanedHandler(handler func(runIDs []string)) {
+	s.onOrphaned = handler
+}
+
 // Write operations
-func (s *Store) CreateTest(req reporting.TestKeepaliveRequest) error {
+func (s *MemoryStore) CreateTest(req reporting.TestKeepaliveRequest, createdBy string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -94,13 +161,16 @@ func (s *Store) CreateTest(req reporting.TestKeepaliveRequest) error {
 		EnclaveName: req.EnclaveName,
 		SystemInfo:  req.SystemInfo,
 		RunTimeout:  req.RunTimeout,
+		CreatedBy:   createdBy,
+		RunnerID:    req.RunnerID,
 		History:     make([]ProgressPoint, 0),
 	}
+	s.version++
 
 	return nil
 }
 
-func (s *Store) UpdateProgress(runID string, metrics reporting.ProgressMetrics) error {
+func (s *MemoryStore) UpdateProgress(runID string, metrics reporting.ProgressMetrics) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -125,11 +195,12 @@ func (s *Store) UpdateProgress(runID string, metrics reporting.ProgressMetrics)
 
 	// Trim history if needed
 	s.trimHistory(test)
+	s.version++
 
 	return nil
 }
 
-func (s *Store) UpdateTestKeepalive(req reporting.TestKeepaliveRequest) error {
+func (s *MemoryStore) UpdateTestKeepalive(req reporting.TestKeepaliveRequest) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -160,11 +231,12 @@ func (s *Store) UpdateTestKeepalive(req reporting.TestKeepaliveRequest) error {
 	if req.RunTimeout > 0 {
 		test.RunTimeout = req.RunTimeout
 	}
+	s.version++
 
 	return nil
 }
 
-func (s *Store) CompleteTest(runID string, req reporting.TestCompleteRequest) error {
+func (s *MemoryStore) CompleteTest(runID string, req reporting.TestCompleteRequest) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -178,13 +250,41 @@ func (s *Store) CompleteTest(runID string, req reporting.TestCompleteRequest) er
 	test.LastUpdate = endTime
 	test.IsRunning = false
 	test.IsComplete = true
+	test.Cancelled = req.Cancelled
 	test.Error = req.Error
+	test.Success = req.Success
+	test.TimedOut = req.TimedOut
+	test.FinalBlock = req.FinalBlock
+	test.FinalSlot = req.FinalSlot
+	s.version++
+
+	return nil
+}
+
+func (s *MemoryStore) RequestCancel(runID, reason, requestedBy string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	test, exists := s.tests[runID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrTestNotFound, runID)
+	}
+
+	if !test.IsRunning {
+		return fmt.Errorf("%w: %s", ErrTestNotRunning, runID)
+	}
+
+	now := time.Now()
+	test.CancelRequestedAt = &now
+	test.CancelRequestedBy = requestedBy
+	test.CancelReason = reason
+	s.version++
 
 	return nil
 }
 
 // Read operations
-func (s *Store) GetTest(runID string) (*TestData, error) {
+func (s *MemoryStore) GetTest(runID string) (*TestData, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -198,7 +298,7 @@ func (s *Store) GetTest(runID string) (*TestData, error) {
 	return &testCopy, nil
 }
 
-func (s *Store) ListTests(activeOnly bool) []TestSummary {
+func (s *MemoryStore) ListTests(activeOnly bool) []TestSummary {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -223,7 +323,13 @@ func (s *Store) ListTests(activeOnly bool) []TestSummary {
 			CurrentMetrics: test.CurrentMetrics,
 			SystemInfo:     test.SystemInfo,
 			RunTimeout:     test.RunTimeout,
+			CreatedBy:      test.CreatedBy,
+			RunnerID:       test.RunnerID,
 			Error:          test.Error,
+
+			CancelRequested: test.CancelRequestedAt != nil,
+			CancelReason:    test.CancelReason,
+			Cancelled:       test.Cancelled,
 		}
 
 		tests = append(tests, summary)
@@ -232,7 +338,7 @@ func (s *Store) ListTests(activeOnly bool) []TestSummary {
 	return tests
 }
 
-func (s *Store) GetTestDetail(runID string) (*TestDetail, error) {
+func (s *MemoryStore) GetTestDetail(runID string) (*TestDetail, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -257,6 +363,12 @@ func (s *Store) GetTestDetail(runID string) (*TestDetail, error) {
 			CurrentMetrics: test.CurrentMetrics,
 			SystemInfo:     test.SystemInfo,
 			RunTimeout:     test.RunTimeout,
+			CreatedBy:      test.CreatedBy,
+			RunnerID:       test.RunnerID,
+
+			CancelRequested: test.CancelRequestedAt != nil,
+			CancelReason:    test.CancelReason,
+			Cancelled:       test.Cancelled,
 		},
 		ProgressHistory: make([]ProgressPoint, len(test.History)),
 		ELClientConfig:  test.ELClient,
@@ -264,6 +376,9 @@ func (s *Store) GetTestDetail(runID string) (*TestDetail, error) {
 		EnclaveName:     test.EnclaveName,
 		EndTime:         test.EndTime,
 		Error:           test.Error,
+
+		CancelRequestedAt: test.CancelRequestedAt,
+		CancelRequestedBy: test.CancelRequestedBy,
 	}
 
 	// Copy history to avoid concurrent modification
@@ -273,7 +388,7 @@ func (s *Store) GetTestDetail(runID string) (*TestDetail, error) {
 }
 
 // MarkOrphanedTests marks tests as orphaned if no keepalive for a given interval
-func (s *Store) MarkOrphanedTests() []string {
+func (s *MemoryStore) MarkOrphanedTests() []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -285,23 +400,25 @@ func (s *Store) MarkOrphanedTests() []string {
 			test.IsRunning = false
 			test.Error = "Test marked as orphaned - no keepalive received for 10 minutes"
 			orphanedTests = append(orphanedTests, runID)
+			s.version++
 		}
 	}
 
 	return orphanedTests
 }
 
-func (s *Store) CleanupOrphanedTests() []string {
+func (s *MemoryStore) CleanupOrphanedTests() []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	var cleanedTests []string
-	cleanupThreshold := time.Now().Add(-20 * time.Minute)
+	cleanupThreshold := time.Now().Add(-s.config.FinishedRetention)
 
 	for runID, test := range s.tests {
 		if !test.IsRunning && test.LastUpdate.Before(cleanupThreshold) {
 			delete(s.tests, runID)
 			cleanedTests = append(cleanedTests, runID)
+			s.version++
 		}
 	}
 
@@ -309,7 +426,7 @@ func (s *Store) CleanupOrphanedTests() []string {
 }
 
 // Maintenance
-func (s *Store) cleanupLoop() {
+func (s *MemoryStore) cleanupLoop() {
 	for {
 		select {
 		case <-s.cleanupTick.C:
@@ -321,37 +438,42 @@ func (s *Store) cleanupLoop() {
 	}
 }
 
-func (s *Store) performOrphanMaintenance() {
+func (s *MemoryStore) performOrphanMaintenance() {
 	// Mark tests as orphaned if no keepalive for 10 minutes
 	orphanedTests := s.MarkOrphanedTests()
 	if len(orphanedTests) > 0 {
 		s.log.WithField("run_id", orphanedTests).Info("Marked tests as orphaned")
+		if s.onOrphaned != nil {
+			s.onOrphaned(orphanedTests)
+		}
 	}
 
-	// Clean up orphaned tests after 20 minutes
+	// Clean up finished and orphaned tests after the retention
 	cleanedTests := s.CleanupOrphanedTests()
 	if len(cleanedTests) > 0 {
 		s.log.WithField("run_id", cleanedTests).Info("Cleaned up orphaned tests")
 	}
 }
 
-func (s *Store) cleanup() {
+func (s *MemoryStore) cleanup() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	cutoff := time.Now().Add(-s.maxAge)
+	cutoff := time.Now().Add(-s.config.MaxAge)
 
 	for runID, test := range s.tests {
 		if test.LastUpdate.Before(cutoff) {
 			delete(s.tests, runID)
+			s.version++
 		}
 	}
 }
 
-func (s *Store) trimHistory(td *TestData) {
-	if len(td.History) > s.maxHistory {
+func (s *MemoryStore) trimHistory(td *TestData) {
+	if s.config.MaxHistory > 0 && len(td.History) > s.config.MaxHistory {
 		// Keep the most recent entries
-		copy(td.History, td.History[len(td.History)-s.maxHistory:])
-		td.History = td.History[:s.maxHistory]
+		td.HistoryDropped += len(td.History) - s.config.MaxHistory
+		copy(td.History, td.History[len(td.History)-s.config.MaxHistory:])
+		td.History = td.History[:s.config.MaxHistory]
 	}
 }
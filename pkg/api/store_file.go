@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrStoreSchemaVersion = errors.New("unsupported store schema version")
+
+// DefaultStoreFlushInterval is how often progress updates are written to the store file.
+// Created, completed and cancelled tests are written right away.
+const DefaultStoreFlushInterval = 5 * time.Second
+
+// storeSchemaVersion is the current version of the store file format. Bump it and
+// migrate older files on load whenever the format changes.
+const storeSchemaVersion = 1
+
+// storeSnapshot is the content of a store file
+type storeSnapshot struct {
+	SchemaVersion int         `json:"schema_version"`
+	SavedAt       time.Time   `json:"saved_at"`
+	Tests         []*TestData `json:"tests"`
+}
+
+// FileStore is a MemoryStore that persists its tests to a JSON file, so they
+// survive server restarts. Test state changes are written right away, progress
+// updates every flush interval and on Stop.
+type FileStore struct {
+	*MemoryStore
+
+	path          string
+	flushInterval time.Duration
+
+	saveMu       sync.Mutex
+	savedVersion uint64
+	flushStop    chan struct{}
+	flushDone    chan struct{}
+}
+
+// NewFileStore creates a store persisted to path, loading existing tests
+func NewFileStore(log logrus.FieldLogger, path string, config StoreConfig) (*FileStore, error) {
+	s := &FileStore{
+		MemoryStore:   NewMemoryStore(log, config),
+		path:          path,
+		flushInterval: DefaultStoreFlushInterval,
+		flushStop:     make(chan struct{}),
+		flushDone:     make(chan struct{}),
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	// Drop tests that expired while the server was down
+	s.cleanup()
+	s.CleanupOrphanedTests()
+
+	return s, nil
+}
+
+func (s *FileStore) String() string {
+	return fmt.Sprintf("file %s (max age %s, finished retention %s)", s.path, s.config.MaxAge, s.config.FinishedRetention)
+}
+
+func (s *FileStore) Start() {
+	s.MemoryStore.Start()
+	go s.flushLoop()
+}
+
+func (s *FileStore) Stop() {
+	close(s.flushStop)
+	<-s.flushDone
+	s.MemoryStore.Stop()
+
+	if err := s.Flush(); err != nil {
+		s.log.WithError(err).Error("Failed to save store")
+	}
+}
+
+// CreateTest creates a test and writes it to the store file
+func (s *FileStore) CreateTest(req reporting.TestKeepaliveRequest, createdBy string) error {
+	if err := s.MemoryStore.CreateTest(req, createdBy); err != nil {
+		return err
+	}
+	s.flushChange()
+	return nil
+}
+
+// CompleteTest completes a test and writes it to the store file
+func (s *FileStore) CompleteTest(runID string, req reporting.TestCompleteRequest) error {
+	if err := s.MemoryStore.CompleteTest(runID, req); err != nil {
+		return err
+	}
+	s.flushChange()
+	return nil
+}
+
+// RequestCancel cancels a test and writes it to the store file
+func (s *FileStore) RequestCancel(runID, reason, requestedBy string) error {
+	if err := s.MemoryStore.RequestCancel(runID, reason, requestedBy); err != nil {
+		return err
+	}
+	s.flushChange()
+	return nil
+}
+
+// flushChange writes a test state change right away. The change is kept in memory if
+// the write fails and retried with the next flush.
+func (s *FileStore) flushChange() {
+	if err := s.Flush(); err != nil {
+		s.log.WithError(err).Error("Failed to save store")
+	}
+}
+
+// Flush writes the tests to the store file if they changed since the last write
+func (s *FileStore) Flush() error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	s.mu.RLock()
+	version := s.version
+	if version == s.savedVersion {
+		s.mu.RUnlock()
+		return nil
+	}
+	snapshot := storeSnapshot{
+		SchemaVersion: storeSchemaVersion,
+		SavedAt:       time.Now(),
+		Tests:         make([]*TestData, 0, len(s.tests)),
+	}
+	for _, test := range s.tests {
+		snapshot.Tests = append(snapshot.Tests, test)
+	}
+	data, err := json.Marshal(snapshot)
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode store: %w", err)
+	}
+
+	// A crash never leaves a partial store file
+	if err := report.WriteFileAtomic(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+
+	s.savedVersion = version
+	return nil
+}
+
+func (s *FileStore) flushLoop() {
+	defer close(s.flushDone)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				s.log.WithError(err).Error("Failed to save store")
+			}
+		case <-s.flushStop:
+			return
+		}
+	}
+}
+
+func (s *FileStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read store: %w", err)
+	}
+
+	var snapshot storeSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode store: %w", err)
+	}
+	if snapshot.SchemaVersion != storeSchemaVersion {
+		return fmt.Errorf("%w: %d", ErrStoreSchemaVersion, snapshot.SchemaVersion)
+	}
+
+	for _, test := range snapshot.Tests {
+		if test == nil || test.RunID == "" {
+			continue
+		}
+		s.tests[test.RunID] = test
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"path":     s.path,
+		"tests":    len(s.tests),
+		"saved_at": snapshot.SavedAt,
+	}).Info("Loaded store")
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/reporting"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStorePersistsTests(t *testing.T) {
+	t.Parallel()
+
+	log := logrus.New()
+	path := filepath.Join(t.TempDir(), "store", "tests.json")
+	now := time.Now().Unix()
+
+	store, err := NewFileStore(log, path, DefaultStoreConfig())
+	require.NoError(t, err)
+	store.Start()
+
+	require.NoError(t, store.CreateTest(reporting.TestKeepaliveRequest{RunID: "run-1", Network: "hoodi", Timestamp: now}, "runner-1"))
+
+	// Created tests are written right away, without waiting for the flush interval
+	written, err := NewFileStore(log, path, DefaultStoreConfig())
+	require.NoError(t, err)
+	_, err = written.GetTest("run-1")
+	require.NoError(t, err)
+
+	require.NoError(t, store.UpdateProgress("run-1", reporting.ProgressMetrics{Block: 42}))
+	store.Stop()
+
+	reopened, err := NewFileStore(log, path, DefaultStoreConfig())
+	require.NoError(t, err)
+
+	detail, err := reopened.GetTestDetail("run-1")
+	require.NoError(t, err)
+	assert.Equal(t, "hoodi", detail.Network)
+	assert.True(t, detail.IsRunning)
+	require.Len(t, detail.ProgressHistory, 1)
+	assert.Equal(t, uint64(42), detail.ProgressHistory[0].Metrics.Block)
+
+	// Tests that expired while the server was down are dropped on load
+	config := DefaultStoreConfig()
+	config.MaxAge = time.Nanosecond
+	expired, err := NewFileStore(log, path, config)
+	require.NoError(t, err)
+	assert.Empty(t, expired.ListTests(false))
+}
+
+func TestFileStoreRejectsNewerSchema(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tests.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schema_version": 99, "tests": []}`), 0o600))
+
+	_, err := NewFileStore(logrus.New(), path, DefaultStoreConfig())
+	require.ErrorIs(t, err, ErrStoreSchemaVersion)
+}
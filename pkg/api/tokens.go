@@ -0,0 +1,315 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/report"
+)
+
+var (
+	ErrTokenExists   = errors.New("token already exists")
+	ErrTokenNotFound = errors.New("token not found")
+	ErrInvalidToken  = errors.New("invalid token")
+)
+
+// Token scopes
+const (
+	ScopeReportWrite = "report-write" // Report test progress and upload reports
+	ScopeRead        = "read"         // Read tests and reports
+	ScopeJobWrite    = "job-write"    // Queue jobs and cancel tests
+	ScopeAdmin       = "admin"        // Manage tokens
+)
+
+// allScopes are granted to the legacy --auth-token
+var allScopes = []string{ScopeReportWrite, ScopeRead, ScopeJobWrite, ScopeAdmin}
+
+// APIToken is a named bearer token. Secrets are given either in plain text or as a
+// hex encoded SHA-256 hash; tokens created through the admin API are stored hashed.
+type APIToken struct {
+	Name        string    `json:"name"`
+	Token       string    `json:"token,omitempty"`
+	TokenSHA256 string    `json:"token_sha256,omitempty"`
+	Scopes      []string  `json:"scopes"`
+	RateLimit   float64   `json:"rate_limit,omitempty"` // Requests per second, 0 for unlimited
+	Burst       int       `json:"burst,omitempty"`      // Defaults to twice the rate limit
+	Revoked     bool      `json:"revoked,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+
+	// legacy marks the --auth-token, which is never written to the tokens file
+	legacy bool
+}
+
+// APITokenInfo describes a token without its secret
+type APITokenInfo struct {
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	RateLimit float64   `json:"rate_limit,omitempty"`
+	Revoked   bool      `json:"revoked"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// tokensFile is the on-disk format of a TokenStore
+type tokensFile struct {
+	Tokens []*APIToken `json:"tokens"`
+}
+
+// TokenStore holds the API tokens of the server and enforces their rate limits
+type TokenStore struct {
+	mu       sync.RWMutex
+	path     string // Empty if changes are not persisted
+	tokens   []*APIToken
+	hashes   map[string]*APIToken // Keyed by SHA-256 of the secret
+	limiters map[string]*rateLimiter
+}
+
+func NewTokenStore() *TokenStore {
+	return &TokenStore{
+		hashes:   make(map[string]*APIToken),
+		limiters: make(map[string]*rateLimiter),
+	}
+}
+
+// LoadTokenStore loads tokens from a JSON file. Tokens added or revoked through the
+// admin API are written back to it.
+func LoadTokenStore(path string) (*TokenStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file: %w", err)
+	}
+
+	var file tokensFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode tokens file: %w", err)
+	}
+
+	store := NewTokenStore()
+	for _, token := range file.Tokens {
+		if err := store.add(token); err != nil {
+			return nil, err
+		}
+	}
+	store.path = path
+
+	return store, nil
+}
+
+// Enabled reports whether any token is configured. Without tokens authentication is disabled.
+func (t *TokenStore) Enabled() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.tokens) > 0
+}
+
+// AddLegacyToken adds a token with all scopes, used for the single shared --auth-token
+func (t *TokenStore) AddLegacyToken(secret string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.add(&APIToken{Name: "default", Token: secret, Scopes: allScopes, legacy: true})
+}
+
+// Create adds a token with a generated secret and returns the secret
+func (t *TokenStore) Create(name string, scopes []string, rateLimit float64) (string, error) {
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	secret := hex.EncodeToString(secretBytes)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	token := &APIToken{
+		Name:        name,
+		TokenSHA256: hashSecret(secret),
+		Scopes:      scopes,
+		RateLimit:   rateLimit,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := t.add(token); err != nil {
+		return "", err
+	}
+	if err := t.save(); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// Revoke disables a token without affecting other tokens
+func (t *TokenStore) Revoke(name string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, token := range t.tokens {
+		if token.Name == name {
+			token.Revoked = true
+			return t.save()
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrTokenNotFound, name)
+}
+
+// List describes all tokens, sorted by name
+func (t *TokenStore) List() []APITokenInfo {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	infos := make([]APITokenInfo, 0, len(t.tokens))
+	for _, token := range t.tokens {
+		infos = append(infos, APITokenInfo{
+			Name:      token.Name,
+			Scopes:    token.Scopes,
+			RateLimit: token.RateLimit,
+			Revoked:   token.Revoked,
+			CreatedAt: token.CreatedAt,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+
+	return infos
+}
+
+// Authenticate returns the active token matching a secret
+func (t *TokenStore) Authenticate(secret string) (*APIToken, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	// Secrets are compared by hash, so lookup time doesn't depend on the secret
+	token, ok := t.hashes[hashSecret(secret)]
+	if !ok || token.Revoked {
+		return nil, false
+	}
+	return token, true
+}
+
+// Allow takes a request from the rate limit of a token. If the limit is exceeded it
+// returns how long to wait for the next request.
+func (t *TokenStore) Allow(token *APIToken) (bool, time.Duration) {
+	t.mu.RLock()
+	limiter := t.limiters[token.Name]
+	t.mu.RUnlock()
+
+	if limiter == nil {
+		return true, 0
+	}
+	return limiter.allow(time.Now())
+}
+
+// add registers a token, must be called with mu held or before the store is shared
+func (t *TokenStore) add(token *APIToken) error {
+	if token.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidToken)
+	}
+	for _, scope := range token.Scopes {
+		if !slices.Contains(allScopes, scope) {
+			return fmt.Errorf("%w: unknown scope %q for %s", ErrInvalidToken, scope, token.Name)
+		}
+	}
+
+	hash := token.TokenSHA256
+	if token.Token != "" {
+		hash = hashSecret(token.Token)
+	}
+	if hash == "" {
+		return fmt.Errorf("%w: %s has no secret", ErrInvalidToken, token.Name)
+	}
+
+	for _, existing := range t.tokens {
+		if existing.Name == token.Name {
+			return fmt.Errorf("%w: %s", ErrTokenExists, token.Name)
+		}
+	}
+	if _, exists := t.hashes[hash]; exists {
+		return fmt.Errorf("%w: %s reuses the secret of another token", ErrTokenExists, token.Name)
+	}
+
+	t.tokens = append(t.tokens, token)
+	t.hashes[hash] = token
+	if token.RateLimit > 0 {
+		burst := float64(token.Burst)
+		if burst <= 0 {
+			burst = math.Max(1, 2*token.RateLimit)
+		}
+		t.limiters[token.Name] = &rateLimiter{rate: token.RateLimit, burst: burst, available: burst}
+	}
+
+	return nil
+}
+
+// save writes the tokens back to their file, must be called with mu held
+func (t *TokenStore) save() error {
+	if t.path == "" {
+		return nil
+	}
+
+	file := tokensFile{Tokens: make([]*APIToken, 0, len(t.tokens))}
+	for _, token := range t.tokens {
+		if !token.legacy {
+			file.Tokens = append(file.Tokens, token)
+		}
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode tokens: %w", err)
+	}
+
+	if err := report.WriteFileAtomic(t.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write tokens file: %w", err)
+	}
+
+	return nil
+}
+
+// hasScope reports whether a token grants a scope
+func (token *APIToken) hasScope(scope string) bool {
+	for _, granted := range token.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// rateLimiter is a token bucket refilled at rate per second up to burst
+type rateLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	available float64
+	last      time.Time
+}
+
+func (l *rateLimiter) allow(now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() {
+		l.available = math.Min(l.burst, l.available+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	if l.available < 1 {
+		return false, time.Duration((1 - l.available) / l.rate * float64(time.Second))
+	}
+	l.available--
+	return true, 0
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopedTokens(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"tokens": [
+		{"name": "runner-1", "token": "secret-1", "scopes": ["report-write"], "rate_limit": 0.001, "burst": 2},
+		{"name": "runner-2", "token": "secret-2", "scopes": ["report-write"]},
+		{"name": "viewer", "token": "secret-3", "scopes": ["read"]}
+	]}`), 0o600))
+
+	tokens, err := LoadTokenStore(path)
+	require.NoError(t, err)
+
+	server := NewServer(logrus.New(), "", "admin-secret")
+	require.NoError(t, server.SetTokenStore(tokens))
+
+	post := func(secret, target, body string) int {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+secret)
+		rec := httptest.NewRecorder()
+		server.router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	keepalive := func(secret, runID string) int {
+		return post(secret, "/api/v1/tests/keepalive", `{"run_id": "`+runID+`", "network": "hoodi"}`)
+	}
+
+	assert.Equal(t, http.StatusOK, keepalive("secret-1", "run-1"))
+	assert.Equal(t, http.StatusForbidden, keepalive("secret-3", "run-2"))
+	assert.Equal(t, http.StatusUnauthorized, keepalive("wrong", "run-2"))
+
+	test, err := server.store.GetTest("run-1")
+	require.NoError(t, err)
+	assert.Equal(t, "runner-1", test.CreatedBy)
+
+	// Only the creating token and admin tokens update a test
+	assert.Equal(t, http.StatusForbidden, keepalive("secret-2", "run-1"))
+	assert.Equal(t, http.StatusForbidden, post("secret-2", "/api/v1/tests/run-1/progress", `{"metrics": {"block": 1}}`))
+	assert.Equal(t, http.StatusForbidden, post("secret-2", "/api/v1/tests/run-1/complete", `{"success": true}`))
+	assert.Equal(t, http.StatusOK, post("admin-secret", "/api/v1/tests/run-1/progress", `{"metrics": {"block": 2}}`))
+	test, err = server.store.GetTest("run-1")
+	require.NoError(t, err)
+	assert.False(t, test.IsComplete)
+
+	// The burst of two is used up by the third request
+	assert.Equal(t, http.StatusOK, keepalive("secret-1", "run-1"))
+	assert.Equal(t, http.StatusTooManyRequests, keepalive("secret-1", "run-1"))
+
+	// Revoking one token leaves the others working
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/tokens/runner-2", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, http.StatusUnauthorized, keepalive("secret-2", "run-3"))
+	assert.Equal(t, http.StatusOK, keepalive("admin-secret", "run-3"))
+
+	reloaded, err := LoadTokenStore(path)
+	require.NoError(t, err)
+	_, ok := reloaded.Authenticate("secret-2")
+	assert.False(t, ok)
+	assert.Len(t, reloaded.List(), 3, "the legacy token is not persisted")
+}
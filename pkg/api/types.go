@@ -33,7 +33,13 @@ type TestSummary struct {
 	CurrentMetrics *reporting.ProgressMetrics `json:"current_metrics,omitempty"`
 	SystemInfo     *sysinfo.SystemInfo        `json:"system_info,omitempty"`
 	RunTimeout     int64                      `json:"run_timeout,omitempty"`
+	CreatedBy      string                     `json:"created_by,omitempty"`
+	RunnerID       string                     `json:"runner_id,omitempty"`
 	Error          string                     `json:"error,omitempty"`
+
+	CancelRequested bool   `json:"cancel_requested,omitempty"` // Cancelled through the API, the runner may not have stopped yet
+	CancelReason    string `json:"cancel_reason,omitempty"`
+	Cancelled       bool   `json:"cancelled,omitempty"` // The runner stopped after the test was cancelled
 }
 
 type TestDetail struct {
@@ -44,6 +50,9 @@ type TestDetail struct {
 	EnclaveName     string                 `json:"enclave_name"`
 	EndTime         *time.Time             `json:"end_time,omitempty"`
 	Error           string                 `json:"error,omitempty"`
+
+	CancelRequestedAt *time.Time `json:"cancel_requested_at,omitempty"`
+	CancelRequestedBy string     `json:"cancel_requested_by,omitempty"`
 }
 
 type ProgressPoint struct {
@@ -55,12 +64,16 @@ type TestListResponse struct {
 	Tests       []TestSummary `json:"tests"`
 	TotalCount  int           `json:"total_count"`
 	ActiveCount int           `json:"active_count"`
+	NextCursor  string        `json:"next_cursor,omitempty"`
 }
 
 // SSE event types
 type SSEEvent struct {
-	Type      string      `json:"type"` // "test_start", "test_progress", "test_complete"
+	ID        uint64      `json:"id"`
+	Type      string      `json:"type"` // One of the Event* types
 	RunID     string      `json:"run_id"`
+	Network   string      `json:"network,omitempty"`
+	RunnerID  string      `json:"runner_id,omitempty"`
 	Timestamp time.Time   `json:"timestamp"`
 	Data      interface{} `json:"data"`
 }
@@ -0,0 +1,157 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultMilestonePercents are the fractions of the final block height milestones are reported for
+var DefaultMilestonePercents = []int{25, 50, 75, 90, 100}
+
+// RunMetrics summarizes the resource usage and timing of a single sync run
+type RunMetrics struct {
+	Duration     int64   `json:"duration"` // Seconds
+	Block        uint64  `json:"block"`
+	Slot         uint64  `json:"slot"`
+	DiskUsageEL  uint64  `json:"disk_usage_el"`
+	DiskUsageCL  uint64  `json:"disk_usage_cl"`
+	PeakMemoryEL uint64  `json:"peak_memory_el"`
+	PeakMemoryCL uint64  `json:"peak_memory_cl"`
+	AvgCPUEL     float64 `json:"avg_cpu_el"`
+	AvgCPUCL     float64 `json:"avg_cpu_cl"`
+	PeakCPUEL    float64 `json:"peak_cpu_el"`
+	PeakCPUCL    float64 `json:"peak_cpu_cl"`
+	IOReadEL     uint64  `json:"io_read_el"`
+	IOWriteEL    uint64  `json:"io_write_el"`
+	IOReadCL     uint64  `json:"io_read_cl"`
+	IOWriteCL    uint64  `json:"io_write_cl"`
+}
+
+// Milestone records when a run first reached a block height
+type Milestone struct {
+	Percent int    `json:"percent"` // Percent of the final block height
+	Block   uint64 `json:"block"`
+	Elapsed int64  `json:"elapsed"` // Seconds since sync start, -1 if never reached
+}
+
+// LoadReport reads a main report file together with the progress file it references.
+// The progress file is verified against the recorded checksum when present.
+func LoadReport(mainFilePath string) (*Result, error) {
+	data, err := os.ReadFile(mainFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read main file: %w", err)
+	}
+
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal main file: %w", err)
+	}
+
+	if err := LoadProgress(mainFilePath, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// LoadProgress reads the progress file referenced by a report loaded from mainFilePath
+// into its sync progress, verifying it against the recorded checksum when present
+func LoadProgress(mainFilePath string, result *Result) error {
+	if result.SyncStatus.SyncProgressFile == "" {
+		return nil
+	}
+
+	progressData, err := os.ReadFile(filepath.Join(filepath.Dir(mainFilePath), result.SyncStatus.SyncProgressFile))
+	if err != nil {
+		return fmt.Errorf("failed to read progress file: %w", err)
+	}
+
+	if result.SyncStatus.SyncProgressHash != "" && checksum(progressData) != result.SyncStatus.SyncProgressHash {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, result.SyncStatus.SyncProgressFile)
+	}
+
+	if err := json.Unmarshal(progressData, &result.SyncStatus.SyncProgress); err != nil {
+		return fmt.Errorf("failed to unmarshal progress file: %w", err)
+	}
+
+	return nil
+}
+
+// ComputeMetrics summarizes a report. Disk usage and block IO are taken from the last
+// progress entry since they are cumulative; memory and CPU are taken over all entries.
+func ComputeMetrics(result *Result) RunMetrics {
+	metrics := RunMetrics{
+		Block: result.SyncStatus.Block,
+		Slot:  result.SyncStatus.Slot,
+	}
+
+	if result.SyncStatus.End > 0 && result.SyncStatus.Start > 0 {
+		metrics.Duration = result.SyncStatus.End - result.SyncStatus.Start
+	}
+
+	entries := result.SyncStatus.SyncProgress
+	if len(entries) == 0 {
+		if last := result.SyncStatus.LastEntry; last != nil {
+			entries = []SyncProgressEntry{*last}
+		}
+	}
+	if len(entries) == 0 {
+		return metrics
+	}
+
+	var cpuSumEL, cpuSumCL float64
+	for _, entry := range entries {
+		metrics.PeakMemoryEL = max(metrics.PeakMemoryEL, entry.MemoryUsageExecutionClient)
+		metrics.PeakMemoryCL = max(metrics.PeakMemoryCL, entry.MemoryUsageConsensusClient)
+		metrics.PeakCPUEL = max(metrics.PeakCPUEL, entry.CPUUsagePercentExecutionClient)
+		metrics.PeakCPUCL = max(metrics.PeakCPUCL, entry.CPUUsagePercentConsensusClient)
+		cpuSumEL += entry.CPUUsagePercentExecutionClient
+		cpuSumCL += entry.CPUUsagePercentConsensusClient
+	}
+	metrics.AvgCPUEL = cpuSumEL / float64(len(entries))
+	metrics.AvgCPUCL = cpuSumCL / float64(len(entries))
+
+	last := entries[len(entries)-1]
+	metrics.DiskUsageEL = last.DiskUsageExecutionClient
+	metrics.DiskUsageCL = last.DiskUsageConsensusClient
+	metrics.IOReadEL = last.BlockIOReadExecutionClient
+	metrics.IOWriteEL = last.BlockIOWriteExecutionClient
+	metrics.IOReadCL = last.BlockIOReadConsensusClient
+	metrics.IOWriteCL = last.BlockIOWriteConsensusClient
+
+	return metrics
+}
+
+// ComputeMilestones returns when the run reached the given percentages of finalBlock
+func ComputeMilestones(result *Result, finalBlock uint64, percents []int) []Milestone {
+	milestones := make([]Milestone, 0, len(percents))
+	for _, percent := range percents {
+		block := finalBlock * uint64(percent) / 100
+		elapsed := int64(-1)
+		if entry, ok := EntryAtBlock(result.SyncStatus.SyncProgress, block); ok {
+			elapsed = entry.T - syncStart(result)
+		}
+		milestones = append(milestones, Milestone{Percent: percent, Block: block, Elapsed: elapsed})
+	}
+	return milestones
+}
+
+// EntryAtBlock returns the first progress entry at or above the given block height
+func EntryAtBlock(entries []SyncProgressEntry, block uint64) (SyncProgressEntry, bool) {
+	for _, entry := range entries {
+		if entry.Block >= block {
+			return entry, true
+		}
+	}
+	return SyncProgressEntry{}, false
+}
+
+// syncStart returns the sync start time, falling back to the first progress entry
+func syncStart(result *Result) int64 {
+	if result.SyncStatus.Start > 0 || len(result.SyncStatus.SyncProgress) == 0 {
+		return result.SyncStatus.Start
+	}
+	return result.SyncStatus.SyncProgress[0].T
+}
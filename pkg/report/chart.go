@@ -0,0 +1,209 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"time"
+)
+
+// Chart value units
+const (
+	ChartUnitCount   = "count"
+	ChartUnitBytes   = "bytes"
+	ChartUnitPercent = "percent"
+)
+
+// DefaultChartPoints is the maximum number of points per chart series
+const DefaultChartPoints = 300
+
+// ChartPoint is a single chart value at an elapsed time in seconds
+type ChartPoint struct {
+	X float64
+	Y float64
+}
+
+// ChartSeries is a named line in a chart
+type ChartSeries struct {
+	Name   string
+	Color  string
+	Points []ChartPoint
+}
+
+// Chart is a time series chart derived from a progress file
+type Chart struct {
+	Title  string
+	Unit   string
+	Series []ChartSeries
+}
+
+// Chart series colors for the execution and consensus client
+const (
+	chartColorEL = "#2563eb"
+	chartColorCL = "#d97706"
+)
+
+// ProgressCharts builds block/slot, disk, memory, CPU and peer charts from the progress
+// entries of a report, downsampled to at most maxPoints points per series
+func ProgressCharts(result *Result, maxPoints int) []Chart {
+	entries := result.SyncStatus.SyncProgress
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if span := entries[len(entries)-1].T - entries[0].T; maxPoints > 0 && len(entries) > maxPoints && span > 0 {
+		interval := time.Duration(math.Ceil(float64(span)/float64(maxPoints))) * time.Second
+		entries = DownsampleProgress(entries, interval)
+	}
+
+	start := syncStart(result)
+	series := func(name, color string, value func(SyncProgressEntry) float64) ChartSeries {
+		points := make([]ChartPoint, 0, len(entries))
+		for _, entry := range entries {
+			points = append(points, ChartPoint{X: float64(entry.T - start), Y: value(entry)})
+		}
+		return ChartSeries{Name: name, Color: color, Points: points}
+	}
+
+	return []Chart{
+		{Title: "Block Height", Unit: ChartUnitCount, Series: []ChartSeries{
+			series("EL block", chartColorEL, func(e SyncProgressEntry) float64 { return float64(e.Block) }),
+		}},
+		{Title: "Slot", Unit: ChartUnitCount, Series: []ChartSeries{
+			series("CL slot", chartColorCL, func(e SyncProgressEntry) float64 { return float64(e.Slot) }),
+		}},
+		{Title: "Disk Usage", Unit: ChartUnitBytes, Series: []ChartSeries{
+			series("EL", chartColorEL, func(e SyncProgressEntry) float64 { return float64(e.DiskUsageExecutionClient) }),
+			series("CL", chartColorCL, func(e SyncProgressEntry) float64 { return float64(e.DiskUsageConsensusClient) }),
+		}},
+		{Title: "Memory Usage", Unit: ChartUnitBytes, Series: []ChartSeries{
+			series("EL", chartColorEL, func(e SyncProgressEntry) float64 { return float64(e.MemoryUsageExecutionClient) }),
+			series("CL", chartColorCL, func(e SyncProgressEntry) float64 { return float64(e.MemoryUsageConsensusClient) }),
+		}},
+		{Title: "CPU Usage", Unit: ChartUnitPercent, Series: []ChartSeries{
+			series("EL", chartColorEL, func(e SyncProgressEntry) float64 { return e.CPUUsagePercentExecutionClient }),
+			series("CL", chartColorCL, func(e SyncProgressEntry) float64 { return e.CPUUsagePercentConsensusClient }),
+		}},
+		{Title: "Peers", Unit: ChartUnitCount, Series: []ChartSeries{
+			series("EL", chartColorEL, func(e SyncProgressEntry) float64 { return float64(e.PeersExecutionClient) }),
+			series("CL", chartColorCL, func(e SyncProgressEntry) float64 { return float64(e.PeersConsensusClient) }),
+		}},
+	}
+}
+
+// Chart layout in SVG user units
+const (
+	chartMarginLeft   = 70
+	chartMarginRight  = 16
+	chartMarginTop    = 32
+	chartMarginBottom = 40
+	chartGridLines    = 4
+)
+
+// SVG renders the chart as a standalone SVG line chart with axes and a legend
+func (c Chart) SVG(width, height int) string {
+	plotWidth := float64(width - chartMarginLeft - chartMarginRight)
+	plotHeight := float64(height - chartMarginTop - chartMarginBottom)
+
+	maxX, maxY := 0.0, 0.0
+	for _, series := range c.Series {
+		for _, point := range series.Points {
+			maxX = max(maxX, point.X)
+			maxY = max(maxY, point.Y)
+		}
+	}
+	maxX = max(maxX, 1)
+	maxY = niceCeiling(maxY)
+
+	scaleX := func(x float64) float64 { return chartMarginLeft + x/maxX*plotWidth }
+	scaleY := func(y float64) float64 { return chartMarginTop + plotHeight - y/maxY*plotHeight }
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" font-family="sans-serif" font-size="11">`,
+		width, height, width, height)
+	fmt.Fprintf(&svg, `<text x="%d" y="18" font-size="13" font-weight="bold">%s</text>`, chartMarginLeft, html.EscapeString(c.Title))
+
+	// Grid lines with value labels, and elapsed time labels along the x axis
+	for i := 0; i <= chartGridLines; i++ {
+		value := maxY * float64(i) / chartGridLines
+		y := scaleY(value)
+		fmt.Fprintf(&svg, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e5e7eb"/>`, chartMarginLeft, y, scaleX(maxX), y)
+		fmt.Fprintf(&svg, `<text x="%d" y="%.1f" text-anchor="end" fill="#6b7280">%s</text>`,
+			chartMarginLeft-6, y+4, html.EscapeString(FormatChartValue(c.Unit, value)))
+
+		elapsed := maxX * float64(i) / chartGridLines
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%d" text-anchor="middle" fill="#6b7280">%s</text>`,
+			scaleX(elapsed), height-chartMarginBottom+16, formatChartElapsed(elapsed))
+	}
+
+	for i, series := range c.Series {
+		if len(series.Points) == 0 {
+			continue
+		}
+
+		coords := make([]string, 0, len(series.Points))
+		for _, point := range series.Points {
+			coords = append(coords, fmt.Sprintf("%.1f,%.1f", scaleX(point.X), scaleY(point.Y)))
+		}
+		fmt.Fprintf(&svg, `<polyline fill="none" stroke="%s" stroke-width="1.5" points="%s"/>`,
+			html.EscapeString(series.Color), strings.Join(coords, " "))
+
+		legendX := width - chartMarginRight - 90*(len(c.Series)-i)
+		fmt.Fprintf(&svg, `<rect x="%d" y="8" width="10" height="10" fill="%s"/>`, legendX, html.EscapeString(series.Color))
+		fmt.Fprintf(&svg, `<text x="%d" y="17">%s</text>`, legendX+14, html.EscapeString(series.Name))
+	}
+
+	svg.WriteString(`</svg>`)
+	return svg.String()
+}
+
+// FormatChartValue formats a chart value for axis labels
+func FormatChartValue(unit string, value float64) string {
+	switch unit {
+	case ChartUnitBytes:
+		units := []string{"B", "KB", "MB", "GB", "TB", "PB"}
+		i := 0
+		for ; value >= 1024 && i < len(units)-1; i++ {
+			value /= 1024
+		}
+		return fmt.Sprintf("%.3g %s", value, units[i])
+	case ChartUnitPercent:
+		return fmt.Sprintf("%.0f%%", value)
+	default:
+		switch {
+		case value >= 1e6:
+			return fmt.Sprintf("%.3gM", value/1e6)
+		case value >= 1e3:
+			return fmt.Sprintf("%.3gk", value/1e3)
+		default:
+			return fmt.Sprintf("%.0f", value)
+		}
+	}
+}
+
+// formatChartElapsed formats elapsed seconds as a compact duration
+func formatChartElapsed(seconds float64) string {
+	switch {
+	case seconds >= 3600:
+		return fmt.Sprintf("%.1fh", seconds/3600)
+	case seconds >= 60:
+		return fmt.Sprintf("%.0fm", seconds/60)
+	default:
+		return fmt.Sprintf("%.0fs", seconds)
+	}
+}
+
+// niceCeiling rounds a maximum up to 1, 2 or 5 times a power of ten
+func niceCeiling(value float64) float64 {
+	if value <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(value)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if value <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressCharts(t *testing.T) {
+	t.Parallel()
+
+	result := &Result{}
+	result.SyncStatus.Start = 1000
+	for i := range 1000 {
+		result.SyncStatus.SyncProgress = append(result.SyncStatus.SyncProgress, SyncProgressEntry{
+			T:                        1000 + int64(i)*10,
+			Block:                    uint64(i),
+			DiskUsageExecutionClient: uint64(i) << 20,
+		})
+	}
+
+	charts := ProgressCharts(result, 100)
+	require.Len(t, charts, 6)
+	for _, chart := range charts {
+		for _, series := range chart.Series {
+			assert.LessOrEqual(t, len(series.Points), 101, chart.Title)
+		}
+	}
+
+	svg := Chart{Title: "<Disk>", Unit: ChartUnitBytes, Series: charts[2].Series}.SVG(600, 200)
+	assert.True(t, strings.HasPrefix(svg, "<svg"))
+	assert.Contains(t, svg, "&lt;Disk&gt;")
+	assert.Equal(t, 2, strings.Count(svg, "<polyline"))
+
+	assert.Empty(t, ProgressCharts(&Result{}, 100))
+	assert.Equal(t, "1.5 GB", FormatChartValue(ChartUnitBytes, 1.5*(1<<30)))
+}
@@ -0,0 +1,206 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownMetric is returned when a threshold references a metric that does not exist
+var ErrUnknownMetric = errors.New("unknown metric")
+
+// MetricDefinition describes a comparable run metric. All metrics are lower-is-better.
+type MetricDefinition struct {
+	Name  string
+	Label string
+	Unit  string // "seconds", "bytes" or "percent"
+	Value func(RunMetrics) float64
+}
+
+// RunMetricDefinitions lists the metrics compared between runs
+var RunMetricDefinitions = []MetricDefinition{
+	{Name: "duration", Label: "Duration", Unit: "seconds", Value: func(m RunMetrics) float64 { return float64(m.Duration) }},
+	{Name: "disk_el", Label: "EL Disk Usage", Unit: "bytes", Value: func(m RunMetrics) float64 { return float64(m.DiskUsageEL) }},
+	{Name: "disk_cl", Label: "CL Disk Usage", Unit: "bytes", Value: func(m RunMetrics) float64 { return float64(m.DiskUsageCL) }},
+	{Name: "peak_memory_el", Label: "EL Peak Memory", Unit: "bytes", Value: func(m RunMetrics) float64 { return float64(m.PeakMemoryEL) }},
+	{Name: "peak_memory_cl", Label: "CL Peak Memory", Unit: "bytes", Value: func(m RunMetrics) float64 { return float64(m.PeakMemoryCL) }},
+	{Name: "avg_cpu_el", Label: "EL Avg CPU", Unit: "percent", Value: func(m RunMetrics) float64 { return m.AvgCPUEL }},
+	{Name: "avg_cpu_cl", Label: "CL Avg CPU", Unit: "percent", Value: func(m RunMetrics) float64 { return m.AvgCPUCL }},
+	{Name: "peak_cpu_el", Label: "EL Peak CPU", Unit: "percent", Value: func(m RunMetrics) float64 { return m.PeakCPUEL }},
+	{Name: "peak_cpu_cl", Label: "CL Peak CPU", Unit: "percent", Value: func(m RunMetrics) float64 { return m.PeakCPUCL }},
+	{Name: "io_read_el", Label: "EL Block IO Read", Unit: "bytes", Value: func(m RunMetrics) float64 { return float64(m.IOReadEL) }},
+	{Name: "io_write_el", Label: "EL Block IO Write", Unit: "bytes", Value: func(m RunMetrics) float64 { return float64(m.IOWriteEL) }},
+	{Name: "io_read_cl", Label: "CL Block IO Read", Unit: "bytes", Value: func(m RunMetrics) float64 { return float64(m.IOReadCL) }},
+	{Name: "io_write_cl", Label: "CL Block IO Write", Unit: "bytes", Value: func(m RunMetrics) float64 { return float64(m.IOWriteCL) }},
+}
+
+// LookupMetric returns the definition of the named metric
+func LookupMetric(name string) (MetricDefinition, error) {
+	for _, definition := range RunMetricDefinitions {
+		if definition.Name == name {
+			return definition, nil
+		}
+	}
+	return MetricDefinition{}, fmt.Errorf("%w: %s", ErrUnknownMetric, name)
+}
+
+// ReportRef identifies one side of a comparison
+type ReportRef struct {
+	RunID     string `json:"run_id"`
+	Timestamp int64  `json:"timestamp"`
+	Network   string `json:"network"`
+	ELClient  string `json:"el_client"`
+	ELVersion string `json:"el_version"`
+	CLClient  string `json:"cl_client"`
+	CLVersion string `json:"cl_version"`
+}
+
+// MetricDelta compares a single metric between two runs
+type MetricDelta struct {
+	Name         string   `json:"name"`
+	Label        string   `json:"label"`
+	Unit         string   `json:"unit"`
+	Base         float64  `json:"base"`
+	Head         float64  `json:"head"`
+	Delta        float64  `json:"delta"`
+	DeltaPercent float64  `json:"delta_percent"` // 0 when the base value is 0
+	Threshold    *float64 `json:"threshold,omitempty"`
+	Regression   bool     `json:"regression"`
+}
+
+// MilestoneDelta compares the time both runs took to reach a block height
+type MilestoneDelta struct {
+	Percent     int    `json:"percent"`
+	Block       uint64 `json:"block"`
+	BaseElapsed int64  `json:"base_elapsed"` // -1 if never reached
+	HeadElapsed int64  `json:"head_elapsed"` // -1 if never reached
+}
+
+// BlockComparison compares both runs at the same block height
+type BlockComparison struct {
+	Block        uint64 `json:"block"`
+	BaseElapsed  int64  `json:"base_elapsed"`
+	HeadElapsed  int64  `json:"head_elapsed"`
+	BaseDiskEL   uint64 `json:"base_disk_el"`
+	HeadDiskEL   uint64 `json:"head_disk_el"`
+	BaseDiskCL   uint64 `json:"base_disk_cl"`
+	HeadDiskCL   uint64 `json:"head_disk_cl"`
+	BaseMemoryEL uint64 `json:"base_memory_el"`
+	HeadMemoryEL uint64 `json:"head_memory_el"`
+}
+
+// ReportDiff is the comparison of a head run against a base run
+type ReportDiff struct {
+	Base         ReportRef         `json:"base"`
+	Head         ReportRef         `json:"head"`
+	Metrics      []MetricDelta     `json:"metrics"`
+	Milestones   []MilestoneDelta  `json:"milestones"`
+	BlockAligned []BlockComparison `json:"block_aligned"`
+	Regressions  []string          `json:"regressions"`
+}
+
+// CompareReports compares head against base. Thresholds map metric names to the maximum
+// allowed increase in percent; exceeding one marks the metric as a regression.
+// Milestones and block-aligned points use the lower of the two final block heights.
+func CompareReports(base, head *Result, thresholds map[string]float64, alignedPoints int) (*ReportDiff, error) {
+	for name := range thresholds {
+		if _, err := LookupMetric(name); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := &ReportDiff{
+		Base:        newReportRef(base),
+		Head:        newReportRef(head),
+		Regressions: make([]string, 0),
+	}
+
+	baseMetrics, headMetrics := ComputeMetrics(base), ComputeMetrics(head)
+	for _, definition := range RunMetricDefinitions {
+		delta := newMetricDelta(definition, definition.Value(baseMetrics), definition.Value(headMetrics))
+		if threshold, ok := thresholds[definition.Name]; ok {
+			delta.Threshold = &threshold
+			// Any increase from a zero baseline is unbounded in percent and exceeds every threshold
+			delta.Regression = delta.DeltaPercent > threshold || (delta.Base == 0 && delta.Head > 0)
+		}
+		if delta.Regression {
+			diff.Regressions = append(diff.Regressions, definition.Name)
+		}
+		diff.Metrics = append(diff.Metrics, delta)
+	}
+
+	finalBlock := min(baseMetrics.Block, headMetrics.Block)
+
+	baseMilestones := ComputeMilestones(base, finalBlock, DefaultMilestonePercents)
+	headMilestones := ComputeMilestones(head, finalBlock, DefaultMilestonePercents)
+	for i := range baseMilestones {
+		diff.Milestones = append(diff.Milestones, MilestoneDelta{
+			Percent:     baseMilestones[i].Percent,
+			Block:       baseMilestones[i].Block,
+			BaseElapsed: baseMilestones[i].Elapsed,
+			HeadElapsed: headMilestones[i].Elapsed,
+		})
+	}
+
+	diff.BlockAligned = compareAtBlocks(base, head, finalBlock, alignedPoints)
+
+	return diff, nil
+}
+
+// compareAtBlocks samples both runs at evenly spaced block heights up to finalBlock
+func compareAtBlocks(base, head *Result, finalBlock uint64, points int) []BlockComparison {
+	comparisons := make([]BlockComparison, 0, points)
+	if finalBlock == 0 || points <= 0 {
+		return comparisons
+	}
+
+	for i := 1; i <= points; i++ {
+		block := finalBlock * uint64(i) / uint64(points)
+
+		baseEntry, baseOK := EntryAtBlock(base.SyncStatus.SyncProgress, block)
+		headEntry, headOK := EntryAtBlock(head.SyncStatus.SyncProgress, block)
+		if !baseOK || !headOK {
+			continue
+		}
+
+		comparisons = append(comparisons, BlockComparison{
+			Block:        block,
+			BaseElapsed:  baseEntry.T - syncStart(base),
+			HeadElapsed:  headEntry.T - syncStart(head),
+			BaseDiskEL:   baseEntry.DiskUsageExecutionClient,
+			HeadDiskEL:   headEntry.DiskUsageExecutionClient,
+			BaseDiskCL:   baseEntry.DiskUsageConsensusClient,
+			HeadDiskCL:   headEntry.DiskUsageConsensusClient,
+			BaseMemoryEL: baseEntry.MemoryUsageExecutionClient,
+			HeadMemoryEL: headEntry.MemoryUsageExecutionClient,
+		})
+	}
+
+	return comparisons
+}
+
+func newMetricDelta(definition MetricDefinition, base, head float64) MetricDelta {
+	delta := MetricDelta{
+		Name:  definition.Name,
+		Label: definition.Label,
+		Unit:  definition.Unit,
+		Base:  base,
+		Head:  head,
+		Delta: head - base,
+	}
+	if base != 0 {
+		delta.DeltaPercent = (head - base) / base * 100
+	}
+	return delta
+}
+
+func newReportRef(result *Result) ReportRef {
+	return ReportRef{
+		RunID:     result.RunID,
+		Timestamp: result.Timestamp,
+		Network:   result.Network,
+		ELClient:  result.ExecutionClientInfo.Type,
+		ELVersion: result.ExecutionClientInfo.Version,
+		CLClient:  result.ConsensusClientInfo.Type,
+		CLVersion: result.ConsensusClientInfo.Version,
+	}
+}
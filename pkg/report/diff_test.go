@@ -0,0 +1,80 @@
+package report
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticRun builds a run syncing 1000 blocks, taking secondsPerBlock per block
+func syntheticRun(secondsPerBlock int64, diskPerBlock uint64) *Result {
+	result := &Result{RunID: "run", Network: "hoodi"}
+	result.SyncStatus.Start = 1000
+	for block := uint64(0); block <= 1000; block += 100 {
+		result.SyncStatus.SyncProgress = append(result.SyncStatus.SyncProgress, SyncProgressEntry{
+			T:                          1000 + int64(block)*secondsPerBlock,
+			Block:                      block,
+			DiskUsageExecutionClient:   block * diskPerBlock,
+			MemoryUsageExecutionClient: 1 << 30,
+		})
+	}
+	result.SyncStatus.End = 1000 + 1000*secondsPerBlock
+	result.SyncStatus.Block = 1000
+	return result
+}
+
+func TestCompareReports(t *testing.T) {
+	t.Parallel()
+
+	base := syntheticRun(2, 10)
+	head := syntheticRun(3, 10)
+
+	diff, err := CompareReports(base, head, map[string]float64{"duration": 10, "disk_el": 5}, 4)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"duration"}, diff.Regressions)
+
+	for _, metric := range diff.Metrics {
+		if metric.Name == "duration" {
+			assert.InDelta(t, 50.0, metric.DeltaPercent, 0.001)
+		}
+	}
+
+	require.Len(t, diff.Milestones, len(DefaultMilestonePercents))
+	assert.Equal(t, MilestoneDelta{Percent: 50, Block: 500, BaseElapsed: 1000, HeadElapsed: 1500}, diff.Milestones[1])
+
+	require.Len(t, diff.BlockAligned, 4)
+	assert.Equal(t, uint64(250), diff.BlockAligned[0].Block)
+	assert.Equal(t, int64(600), diff.BlockAligned[0].BaseElapsed) // First entry at or above block 250 is block 300
+
+	// Growth from a zero baseline has no percent change but still regresses
+	diff, err = CompareReports(syntheticRun(2, 0), syntheticRun(2, 10), map[string]float64{"disk_el": 5, "duration": 10}, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"disk_el"}, diff.Regressions)
+
+	_, err = CompareReports(base, head, map[string]float64{"nope": 1}, 4)
+	require.ErrorIs(t, err, ErrUnknownMetric)
+}
+
+func TestLoadReportIncludesProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	svc := newTestService(t)
+	for i := range 5 {
+		require.NoError(t, svc.AddSyncProgressEntry(ctx, SyncProgressEntry{T: int64(i), Block: uint64(i)}))
+	}
+	require.NoError(t, svc.SaveReportToFiles(ctx, "hoodi_geth_teku", dir))
+
+	mainFiles, err := filepath.Glob(filepath.Join(dir, "*.main.json"))
+	require.NoError(t, err)
+	require.Len(t, mainFiles, 1)
+
+	loaded, err := LoadReport(mainFiles[0])
+	require.NoError(t, err)
+	assert.Len(t, loaded.SyncStatus.SyncProgress, 5)
+}
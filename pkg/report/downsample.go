@@ -0,0 +1,80 @@
+package report
+
+import (
+	"time"
+)
+
+// ProgressResolution describes a downsampled progress series resolution
+type ProgressResolution struct {
+	Name     string
+	Interval time.Duration
+}
+
+// DefaultProgressResolutions are the downsampled series emitted alongside the full progress file
+var DefaultProgressResolutions = []ProgressResolution{ //nolint:gochecknoglobals // read-only defaults
+	{Name: "1m", Interval: time.Minute},
+	{Name: "10m", Interval: 10 * time.Minute},
+}
+
+// ProgressSeries references a progress file at a specific resolution
+type ProgressSeries struct {
+	Resolution   string `json:"resolution"`
+	Interval     int64  `json:"interval"` // Bucket size in seconds
+	File         string `json:"file"`
+	EntriesCount int    `json:"entries_count"`
+	Checksum     string `json:"checksum,omitempty"`
+}
+
+// DownsampleProgress reduces a progress series to one entry per interval bucket.
+// Block, slot and peer counts take the last value in the bucket while disk,
+// memory, IO and CPU take the bucket maximum so that peaks remain visible.
+func DownsampleProgress(entries []SyncProgressEntry, interval time.Duration) []SyncProgressEntry {
+	bucketSize := int64(interval.Seconds())
+	if bucketSize <= 0 || len(entries) == 0 {
+		return entries
+	}
+
+	result := make([]SyncProgressEntry, 0, len(entries))
+	currentBucket := entries[0].T / bucketSize
+
+	var acc SyncProgressEntry
+	for i, entry := range entries {
+		bucket := entry.T / bucketSize
+		if i == 0 {
+			acc = entry
+			continue
+		}
+
+		if bucket != currentBucket {
+			result = append(result, acc)
+			acc = entry
+			currentBucket = bucket
+			continue
+		}
+
+		mergeProgressEntry(&acc, entry)
+	}
+
+	return append(result, acc)
+}
+
+// mergeProgressEntry folds next into acc keeping last values and resource peaks
+func mergeProgressEntry(acc *SyncProgressEntry, next SyncProgressEntry) {
+	acc.T = next.T
+	acc.Block = next.Block
+	acc.Slot = next.Slot
+	acc.PeersExecutionClient = next.PeersExecutionClient
+	acc.PeersConsensusClient = next.PeersConsensusClient
+
+	acc.DiskUsageExecutionClient = max(acc.DiskUsageExecutionClient, next.DiskUsageExecutionClient)
+	acc.MemoryUsageExecutionClient = max(acc.MemoryUsageExecutionClient, next.MemoryUsageExecutionClient)
+	acc.BlockIOReadExecutionClient = max(acc.BlockIOReadExecutionClient, next.BlockIOReadExecutionClient)
+	acc.BlockIOWriteExecutionClient = max(acc.BlockIOWriteExecutionClient, next.BlockIOWriteExecutionClient)
+	acc.CPUUsagePercentExecutionClient = max(acc.CPUUsagePercentExecutionClient, next.CPUUsagePercentExecutionClient)
+
+	acc.DiskUsageConsensusClient = max(acc.DiskUsageConsensusClient, next.DiskUsageConsensusClient)
+	acc.MemoryUsageConsensusClient = max(acc.MemoryUsageConsensusClient, next.MemoryUsageConsensusClient)
+	acc.BlockIOReadConsensusClient = max(acc.BlockIOReadConsensusClient, next.BlockIOReadConsensusClient)
+	acc.BlockIOWriteConsensusClient = max(acc.BlockIOWriteConsensusClient, next.BlockIOWriteConsensusClient)
+	acc.CPUUsagePercentConsensusClient = max(acc.CPUUsagePercentConsensusClient, next.CPUUsagePercentConsensusClient)
+}
@@ -0,0 +1,32 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownsampleProgressKeepsPeaks(t *testing.T) {
+	t.Parallel()
+
+	entries := make([]SyncProgressEntry, 0, 120)
+	for i := range 120 {
+		entries = append(entries, SyncProgressEntry{
+			T:                          int64(i * 10),
+			Block:                      uint64(i),
+			MemoryUsageExecutionClient: 100,
+		})
+	}
+	entries[37].MemoryUsageExecutionClient = 5000
+
+	downsampled := DownsampleProgress(entries, time.Minute)
+	require.Len(t, downsampled, 20)
+
+	// Each bucket reports its last block and the peak memory usage
+	assert.Equal(t, uint64(5), downsampled[0].Block)
+	assert.Equal(t, uint64(41), downsampled[6].Block)
+	assert.Equal(t, uint64(5000), downsampled[6].MemoryUsageExecutionClient)
+	assert.Equal(t, uint64(100), downsampled[7].MemoryUsageExecutionClient)
+}
@@ -0,0 +1,206 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ProgressField describes a progress entry value in exported data
+type ProgressField struct {
+	Column string // CSV and columnar column name
+	Metric string // OpenMetrics metric family
+	Layer  string // "execution" or "consensus"
+	Help   string
+	Value  func(SyncProgressEntry) float64
+}
+
+// ProgressFields lists the exported progress entry values
+var ProgressFields = []ProgressField{
+	{"block", "syncoor_block_height", "execution", "Execution client block number",
+		func(e SyncProgressEntry) float64 { return float64(e.Block) }},
+	{"slot", "syncoor_slot", "consensus", "Consensus client slot number",
+		func(e SyncProgressEntry) float64 { return float64(e.Slot) }},
+	{"peers_el", "syncoor_peers", "execution", "Connected peers",
+		func(e SyncProgressEntry) float64 { return float64(e.PeersExecutionClient) }},
+	{"peers_cl", "syncoor_peers", "consensus", "Connected peers",
+		func(e SyncProgressEntry) float64 { return float64(e.PeersConsensusClient) }},
+	{"disk_el", "syncoor_disk_usage_bytes", "execution", "Client disk usage",
+		func(e SyncProgressEntry) float64 { return float64(e.DiskUsageExecutionClient) }},
+	{"disk_cl", "syncoor_disk_usage_bytes", "consensus", "Client disk usage",
+		func(e SyncProgressEntry) float64 { return float64(e.DiskUsageConsensusClient) }},
+	{"memory_el", "syncoor_memory_usage_bytes", "execution", "Client memory usage",
+		func(e SyncProgressEntry) float64 { return float64(e.MemoryUsageExecutionClient) }},
+	{"memory_cl", "syncoor_memory_usage_bytes", "consensus", "Client memory usage",
+		func(e SyncProgressEntry) float64 { return float64(e.MemoryUsageConsensusClient) }},
+	{"cpu_el", "syncoor_cpu_usage_percent", "execution", "Client CPU usage",
+		func(e SyncProgressEntry) float64 { return e.CPUUsagePercentExecutionClient }},
+	{"cpu_cl", "syncoor_cpu_usage_percent", "consensus", "Client CPU usage",
+		func(e SyncProgressEntry) float64 { return e.CPUUsagePercentConsensusClient }},
+	{"io_read_el", "syncoor_block_io_read_bytes", "execution", "Cumulative client block IO read",
+		func(e SyncProgressEntry) float64 { return float64(e.BlockIOReadExecutionClient) }},
+	{"io_read_cl", "syncoor_block_io_read_bytes", "consensus", "Cumulative client block IO read",
+		func(e SyncProgressEntry) float64 { return float64(e.BlockIOReadConsensusClient) }},
+	{"io_write_el", "syncoor_block_io_write_bytes", "execution", "Cumulative client block IO write",
+		func(e SyncProgressEntry) float64 { return float64(e.BlockIOWriteExecutionClient) }},
+	{"io_write_cl", "syncoor_block_io_write_bytes", "consensus", "Cumulative client block IO write",
+		func(e SyncProgressEntry) float64 { return float64(e.BlockIOWriteConsensusClient) }},
+}
+
+// exportIdentityColumns are the per-row run and time columns preceding the progress fields
+var exportIdentityColumns = []string{"run_id", "network", "el_client", "cl_client", "t", "elapsed"}
+
+// ExportCSV writes one row per progress entry of every run
+func ExportCSV(w io.Writer, runs []*Result) error {
+	writer := csv.NewWriter(w)
+
+	header := append([]string(nil), exportIdentityColumns...)
+	for _, field := range ProgressFields {
+		header = append(header, field.Column)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		start := syncStart(run)
+		for _, entry := range run.SyncStatus.SyncProgress {
+			row := []string{
+				run.RunID, run.Network, run.ExecutionClientInfo.Type, run.ConsensusClientInfo.Type,
+				strconv.FormatInt(entry.T, 10), strconv.FormatInt(entry.T-start, 10),
+			}
+			for _, field := range ProgressFields {
+				row = append(row, formatExportValue(field.Value(entry)))
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ColumnarExport is a column-oriented table of progress entries that loads directly
+// into data frame libraries, e.g. pandas.DataFrame(export["columns"])
+type ColumnarExport struct {
+	Schema  []ColumnSchema         `json:"schema"`
+	NumRows int                    `json:"num_rows"`
+	Columns map[string]interface{} `json:"columns"`
+}
+
+// ColumnSchema describes a column of a columnar export
+type ColumnSchema struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // "string", "int64" or "float64"
+}
+
+// ExportColumnar writes the progress entries of every run as a columnar JSON table
+func ExportColumnar(w io.Writer, runs []*Result) error {
+	var (
+		runIDs, networks, elClients, clClients []string
+		timestamps, elapsed                    []int64
+	)
+	values := make([][]float64, len(ProgressFields))
+
+	for _, run := range runs {
+		start := syncStart(run)
+		for _, entry := range run.SyncStatus.SyncProgress {
+			runIDs = append(runIDs, run.RunID)
+			networks = append(networks, run.Network)
+			elClients = append(elClients, run.ExecutionClientInfo.Type)
+			clClients = append(clClients, run.ConsensusClientInfo.Type)
+			timestamps = append(timestamps, entry.T)
+			elapsed = append(elapsed, entry.T-start)
+			for i, field := range ProgressFields {
+				values[i] = append(values[i], field.Value(entry))
+			}
+		}
+	}
+
+	export := ColumnarExport{
+		NumRows: len(timestamps),
+		Columns: map[string]interface{}{
+			"run_id": nonNil(runIDs), "network": nonNil(networks), "el_client": nonNil(elClients), "cl_client": nonNil(clClients),
+			"t": nonNil(timestamps), "elapsed": nonNil(elapsed),
+		},
+	}
+	for _, name := range exportIdentityColumns {
+		columnType := "string"
+		if name == "t" || name == "elapsed" {
+			columnType = "int64"
+		}
+		export.Schema = append(export.Schema, ColumnSchema{Name: name, Type: columnType})
+	}
+	for i, field := range ProgressFields {
+		export.Schema = append(export.Schema, ColumnSchema{Name: field.Column, Type: "float64"})
+		export.Columns[field.Column] = nonNil(values[i])
+	}
+
+	encoder := json.NewEncoder(w)
+	return encoder.Encode(export)
+}
+
+// ExportOpenMetrics writes the progress entries of every run as timestamped OpenMetrics
+// samples, suitable for backfilling with `promtool tsdb create-blocks-from openmetrics`
+func ExportOpenMetrics(w io.Writer, runs []*Result) error {
+	var out strings.Builder
+
+	// Samples of a metric family must be contiguous, so families are written one at a time
+	for i, field := range ProgressFields {
+		if i == 0 || ProgressFields[i-1].Metric != field.Metric {
+			fmt.Fprintf(&out, "# HELP %s %s.\n# TYPE %s gauge\n", field.Metric, field.Help, field.Metric)
+			if unit := metricUnit(field.Metric); unit != "" {
+				fmt.Fprintf(&out, "# UNIT %s %s\n", field.Metric, unit)
+			}
+		}
+
+		for _, run := range runs {
+			labels := fmt.Sprintf(`{run_id="%s",network="%s",el_client="%s",cl_client="%s",layer="%s"}`,
+				escapeLabel(run.RunID), escapeLabel(run.Network), escapeLabel(run.ExecutionClientInfo.Type),
+				escapeLabel(run.ConsensusClientInfo.Type), field.Layer)
+			for _, entry := range run.SyncStatus.SyncProgress {
+				fmt.Fprintf(&out, "%s%s %s %d\n", field.Metric, labels, formatExportValue(field.Value(entry)), entry.T)
+			}
+		}
+
+		if _, err := io.WriteString(w, out.String()); err != nil {
+			return err
+		}
+		out.Reset()
+	}
+
+	_, err := io.WriteString(w, "# EOF\n")
+	return err
+}
+
+// metricUnit returns the OpenMetrics unit encoded in a metric name suffix
+func metricUnit(metric string) string {
+	for _, unit := range []string{"bytes", "percent"} {
+		if strings.HasSuffix(metric, "_"+unit) {
+			return unit
+		}
+	}
+	return ""
+}
+
+// escapeLabel escapes an OpenMetrics label value
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatExportValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// nonNil returns an empty slice for nil so columns encode as [] rather than null
+func nonNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
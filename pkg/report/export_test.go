@@ -0,0 +1,42 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFormats(t *testing.T) {
+	t.Parallel()
+
+	run := syntheticRun(2, 10)
+	run.ExecutionClientInfo.Type = "geth"
+	run.ConsensusClientInfo.Type = `te"ku`
+	runs := []*Result{run}
+
+	var csvOut bytes.Buffer
+	require.NoError(t, ExportCSV(&csvOut, runs))
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	require.Len(t, lines, 12)
+	assert.True(t, strings.HasPrefix(lines[0], "run_id,network,el_client,cl_client,t,elapsed,block,slot"))
+	assert.True(t, strings.HasPrefix(lines[2], `run,hoodi,geth,"te""ku",1200,200,100,`))
+
+	var columnarOut bytes.Buffer
+	require.NoError(t, ExportColumnar(&columnarOut, runs))
+	var export ColumnarExport
+	require.NoError(t, json.Unmarshal(columnarOut.Bytes(), &export))
+	assert.Equal(t, 11, export.NumRows)
+	assert.Len(t, export.Schema, len(exportIdentityColumns)+len(ProgressFields))
+
+	var metricsOut bytes.Buffer
+	require.NoError(t, ExportOpenMetrics(&metricsOut, runs))
+	metrics := metricsOut.String()
+	assert.True(t, strings.HasSuffix(metrics, "# EOF\n"))
+	assert.Equal(t, 1, strings.Count(metrics, "# TYPE syncoor_disk_usage_bytes gauge"))
+	assert.Contains(t, metrics, "# UNIT syncoor_disk_usage_bytes bytes")
+	assert.Contains(t, metrics, `syncoor_block_height{run_id="run",network="hoodi",el_client="geth",cl_client="te\"ku",layer="execution"} 500 2000`)
+}
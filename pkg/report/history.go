@@ -0,0 +1,92 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+)
+
+// ErrNoHistory is returned when a history source contains no main report files
+var ErrNoHistory = errors.New("no historical reports found")
+
+// HistoryFiles returns the paths of the main report files in a report history: all main
+// report files below reportDir, or the files listed in the index at indexFile including
+// the shard files of a sharded index. Both are filtered by the include and exclude patterns.
+func HistoryFiles(ctx context.Context, reportDir, indexFile string, include, exclude []string) ([]string, error) {
+	matcher, err := newFileMatcher(include, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	root, source := reportDir, reportDir
+	var keys []string
+	if indexFile != "" {
+		root, source = filepath.Dir(indexFile), indexFile
+		if keys, err = indexMainFiles(ctx, NewLocalStorage(root), filepath.Base(indexFile)); err != nil {
+			return nil, err
+		}
+	} else {
+		objects, err := NewLocalStorage(reportDir).List(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			keys = append(keys, object.Key)
+		}
+	}
+
+	files := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if matcher.matches(key) {
+			files = append(files, filepath.Join(root, filepath.FromSlash(key)))
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoHistory, source)
+	}
+
+	return files, nil
+}
+
+// indexMainFiles returns the main files listed in the index at key and in its shard files.
+// Shard files and main files are relative to the index.
+func indexMainFiles(ctx context.Context, storage Storage, key string) ([]string, error) {
+	index, err := readIndex(ctx, storage, key)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := index.Entries
+	for _, shard := range index.Shards {
+		for _, file := range shard.Files {
+			page, err := readIndex(ctx, storage, path.Join(path.Dir(key), file))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read shard %s: %w", shard.Name, err)
+			}
+			entries = append(entries, page.Entries...)
+		}
+	}
+
+	mainFiles := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		mainFiles = append(mainFiles, path.Join(path.Dir(key), entry.MainFile))
+	}
+	return mainFiles, nil
+}
+
+// readIndex reads and parses the index file stored under key
+func readIndex(ctx context.Context, storage Storage, key string) (*Index, error) {
+	data, err := storage.Read(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index: %w", err)
+	}
+	return &index, nil
+}
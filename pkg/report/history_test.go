@@ -0,0 +1,53 @@
+package report
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryFiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	root := t.TempDir()
+
+	for _, sub := range []string{"ci/hoodi", "ci/sepolia", "nightly/hoodi"} {
+		svc := newTestService(t)
+		require.NoError(t, svc.AddSyncProgressEntry(ctx, SyncProgressEntry{T: 1, Block: 1}))
+		require.NoError(t, svc.SaveReportToFiles(ctx, "hoodi_geth_teku", filepath.Join(root, sub)))
+	}
+
+	files, err := HistoryFiles(ctx, root, "", nil, []string{"nightly/**"})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, filepath.Join(root, "ci", "hoodi"), filepath.Dir(files[0]))
+
+	// Sharded index with one entry per shard page; the manifest has no entries of its own
+	log := logrus.New()
+	log.SetLevel(logrus.WarnLevel)
+	indexService := NewIndexService(log)
+	storage := NewLocalStorage(root)
+	index, err := indexService.GenerateIndexFromStorage(ctx, storage)
+	require.NoError(t, err)
+	require.Len(t, index.Entries, 3)
+
+	manifest, shards, err := ShardIndex(index, ShardByNetwork, "index", 1)
+	require.NoError(t, err)
+	require.Len(t, shards, 3)
+	for file, shard := range shards {
+		require.NoError(t, indexService.SaveIndexToStorage(ctx, shard, storage, file))
+	}
+	require.NoError(t, indexService.SaveIndexToStorage(ctx, manifest, storage, "index.json"))
+
+	files, err = HistoryFiles(ctx, "", filepath.Join(root, "index.json"), []string{"ci/**"}, nil)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	_, err = HistoryFiles(ctx, filepath.Join(root, "missing"), "", nil, nil)
+	require.ErrorIs(t, err, ErrNoHistory)
+}
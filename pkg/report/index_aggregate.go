@@ -0,0 +1,156 @@
+package report
+
+import (
+	"sort"
+)
+
+// DefaultTrendRuns is the number of recent runs included in an aggregate trend
+const DefaultTrendRuns = 10
+
+// IndexAggregate summarizes all indexed runs of a network and client pair
+type IndexAggregate struct {
+	Network        string            `json:"network"`
+	ELClient       string            `json:"el_client"`
+	CLClient       string            `json:"cl_client"`
+	Runs           int               `json:"runs"`
+	Successes      int               `json:"successes"`
+	SuccessRate    float64           `json:"success_rate"` // 0 to 1
+	MedianDuration float64           `json:"median_duration"`
+	P90Duration    float64           `json:"p90_duration"`
+	MedianDiskEL   float64           `json:"median_disk_el"`
+	MedianDiskCL   float64           `json:"median_disk_cl"`
+	LastSuccess    *IndexRunSummary  `json:"last_success,omitempty"`
+	Trend          []IndexRunSummary `json:"trend"`           // Most recent runs, oldest first
+	DurationChange float64           `json:"duration_change"` // Median duration change across the trend, in percent
+}
+
+// IndexRunSummary is a compact reference to an indexed run
+type IndexRunSummary struct {
+	RunID     string `json:"run_id"`
+	Timestamp int64  `json:"timestamp"`
+	Status    string `json:"status,omitempty"`
+	Duration  int64  `json:"duration"`
+	Block     uint64 `json:"block"`
+	DiskEL    uint64 `json:"disk_el"`
+	DiskCL    uint64 `json:"disk_cl"`
+	MainFile  string `json:"main_file"`
+}
+
+// ComputeAggregates groups entries by network and client pair and summarizes each group.
+// Duration and disk statistics only consider successful runs. The trend holds the last
+// trendRuns runs of any status.
+func ComputeAggregates(entries []IndexEntry, trendRuns int) []IndexAggregate {
+	groups := make(map[[3]string][]IndexEntry)
+	for _, entry := range entries {
+		key := [3]string{entry.Network, entry.ExecutionClientInfo.Type, entry.ConsensusClientInfo.Type}
+		groups[key] = append(groups[key], entry)
+	}
+
+	aggregates := make([]IndexAggregate, 0, len(groups))
+	for key, group := range groups {
+		aggregates = append(aggregates, newIndexAggregate(key[0], key[1], key[2], group, trendRuns))
+	}
+
+	sort.Slice(aggregates, func(i, j int) bool {
+		a, b := aggregates[i], aggregates[j]
+		if a.Network != b.Network {
+			return a.Network < b.Network
+		}
+		if a.ELClient != b.ELClient {
+			return a.ELClient < b.ELClient
+		}
+		return a.CLClient < b.CLClient
+	})
+
+	return aggregates
+}
+
+// newIndexAggregate summarizes the runs of one group
+func newIndexAggregate(network, elClient, clClient string, group []IndexEntry, trendRuns int) IndexAggregate {
+	sort.Slice(group, func(i, j int) bool { return group[i].Timestamp < group[j].Timestamp })
+
+	aggregate := IndexAggregate{
+		Network:  network,
+		ELClient: elClient,
+		CLClient: clClient,
+		Runs:     len(group),
+		Trend:    make([]IndexRunSummary, 0, min(len(group), max(trendRuns, 0))),
+	}
+
+	var durations, diskEL, diskCL []float64
+	for i := range group {
+		if group[i].SyncInfo.Status != "success" {
+			continue
+		}
+
+		summary := newIndexRunSummary(&group[i])
+		aggregate.Successes++
+		aggregate.LastSuccess = &summary
+		durations = append(durations, float64(summary.Duration))
+		diskEL = append(diskEL, float64(summary.DiskEL))
+		diskCL = append(diskCL, float64(summary.DiskCL))
+	}
+
+	aggregate.SuccessRate = float64(aggregate.Successes) / float64(aggregate.Runs)
+	aggregate.MedianDuration = percentile(durations, 0.5)
+	aggregate.P90Duration = percentile(durations, 0.9)
+	aggregate.MedianDiskEL = percentile(diskEL, 0.5)
+	aggregate.MedianDiskCL = percentile(diskCL, 0.5)
+
+	if trendRuns > 0 {
+		for i := max(len(group)-trendRuns, 0); i < len(group); i++ {
+			aggregate.Trend = append(aggregate.Trend, newIndexRunSummary(&group[i]))
+		}
+	}
+	aggregate.DurationChange = durationChange(aggregate.Trend)
+
+	return aggregate
+}
+
+// durationChange compares the median duration of successful runs in the newer half of a
+// trend against the older half, in percent
+func durationChange(trend []IndexRunSummary) float64 {
+	var older, newer []float64
+	for i, run := range trend {
+		if run.Status != "success" {
+			continue
+		}
+		if i < len(trend)/2 {
+			older = append(older, float64(run.Duration))
+		} else {
+			newer = append(newer, float64(run.Duration))
+		}
+	}
+
+	base := percentile(older, 0.5)
+	if base == 0 || len(newer) == 0 {
+		return 0
+	}
+	return (percentile(newer, 0.5) - base) / base * 100
+}
+
+// percentile returns the q-th quantile of unsorted samples, or 0 without samples
+func percentile(samples []float64, q float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	return quantile(sorted, q)
+}
+
+func newIndexRunSummary(entry *IndexEntry) IndexRunSummary {
+	summary := IndexRunSummary{
+		RunID:     entry.RunID,
+		Timestamp: entry.Timestamp,
+		Status:    entry.SyncInfo.Status,
+		Duration:  entry.SyncInfo.Duration,
+		Block:     entry.SyncInfo.Block,
+		MainFile:  entry.MainFile,
+	}
+	if entry.SyncInfo.LastEntry != nil {
+		summary.DiskEL = entry.SyncInfo.LastEntry.DiskUsageExecutionClient
+		summary.DiskCL = entry.SyncInfo.LastEntry.DiskUsageConsensusClient
+	}
+	return summary
+}
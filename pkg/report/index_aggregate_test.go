@@ -0,0 +1,47 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeAggregates(t *testing.T) {
+	t.Parallel()
+
+	run := func(runID, el, status string, timestamp, duration int64, disk uint64) IndexEntry {
+		entry := IndexEntry{RunID: runID, Network: "hoodi", Timestamp: timestamp}
+		entry.ExecutionClientInfo.Type = el
+		entry.ConsensusClientInfo.Type = "teku"
+		entry.SyncInfo = IndexSyncInfo{Status: status, Duration: duration, LastEntry: &SyncProgressEntry{DiskUsageExecutionClient: disk}}
+		return entry
+	}
+
+	aggregates := ComputeAggregates([]IndexEntry{
+		run("d", "geth", "success", 4, 200, 40),
+		run("a", "geth", "success", 1, 100, 10),
+		run("c", "geth", "timeout", 3, 0, 30),
+		run("b", "geth", "success", 2, 100, 20),
+		run("r", "reth", "error", 1, 0, 0),
+	}, 3)
+	require.Len(t, aggregates, 2)
+
+	geth := aggregates[0]
+	assert.Equal(t, "geth", geth.ELClient)
+	assert.Equal(t, 4, geth.Runs)
+	assert.InDelta(t, 0.75, geth.SuccessRate, 0.001)
+	assert.InDelta(t, 100.0, geth.MedianDuration, 0.001)
+	assert.InDelta(t, 180.0, geth.P90Duration, 0.001)
+	assert.InDelta(t, 20.0, geth.MedianDiskEL, 0.001)
+	require.NotNil(t, geth.LastSuccess)
+	assert.Equal(t, "d", geth.LastSuccess.RunID)
+
+	require.Len(t, geth.Trend, 3)
+	assert.Equal(t, []string{"b", "c", "d"}, []string{geth.Trend[0].RunID, geth.Trend[1].RunID, geth.Trend[2].RunID})
+	assert.InDelta(t, 100.0, geth.DurationChange, 0.001)
+
+	reth := aggregates[1]
+	assert.Zero(t, reth.SuccessRate)
+	assert.Nil(t, reth.LastSuccess)
+}
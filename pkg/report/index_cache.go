@@ -0,0 +1,152 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// indexCacheVersion is bumped whenever cached entries need to be rebuilt
+const indexCacheVersion = 1
+
+// IndexCache keeps processed index entries keyed by main report file, so regenerating
+// an index only re-reads reports whose main or progress file changed size or mtime
+type IndexCache struct {
+	mu    sync.Mutex
+	path  string // Empty for an in-memory cache
+	files map[string]*indexCacheEntry
+}
+
+// indexCacheEntry is the cached result of processing one main report file
+type indexCacheEntry struct {
+	Size            int64            `json:"size"`
+	ModTime         int64            `json:"mod_time"` // Unix nanoseconds
+	ProgressFile    string           `json:"progress_file,omitempty"`
+	ProgressSize    int64            `json:"progress_size,omitempty"`
+	ProgressModTime int64            `json:"progress_mod_time,omitempty"`
+	Entry           *IndexEntry      `json:"entry,omitempty"`
+	Corrupted       *CorruptedReport `json:"corrupted,omitempty"`
+}
+
+// indexCacheFile is the on-disk format of an IndexCache
+type indexCacheFile struct {
+	Version int                         `json:"version"`
+	Files   map[string]*indexCacheEntry `json:"files"`
+}
+
+// NewIndexCache creates an index cache. If path is not empty the cache is loaded from
+// and saved to that file; a missing or outdated file starts an empty cache.
+func NewIndexCache(path string) (*IndexCache, error) {
+	cache := &IndexCache{path: path, files: make(map[string]*indexCacheEntry)}
+	if path == "" {
+		return cache, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index cache: %w", err)
+	}
+
+	var file indexCacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != indexCacheVersion || file.Files == nil {
+		return cache, nil
+	}
+	cache.files = file.Files
+
+	return cache, nil
+}
+
+// Len returns the number of cached report files
+func (c *IndexCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.files)
+}
+
+// save writes the cache to disk if it has a path
+func (c *IndexCache) save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(indexCacheFile{Version: indexCacheVersion, Files: c.files})
+	if err != nil {
+		return fmt.Errorf("failed to marshal index cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	return WriteFileAtomic(c.path, data, 0644)
+}
+
+// fresh reports whether a cached entry still matches the main and progress file stats
+func (e *indexCacheEntry) fresh(main ObjectInfo, progress ObjectInfo, hasProgress bool) bool {
+	if e.Size != main.Size || e.ModTime != main.ModTime.UnixNano() {
+		return false
+	}
+	if e.ProgressFile == "" {
+		return true
+	}
+	return hasProgress && e.ProgressSize == progress.Size && e.ProgressModTime == progress.ModTime.UnixNano()
+}
+
+// index builds an index from the cached entries, ordered by main file
+func (c *IndexCache) index(generated int64) *Index {
+	keys := make([]string, 0, len(c.files))
+	for key := range c.files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	index := &Index{Generated: generated, Entries: make([]IndexEntry, 0, len(keys))}
+	for _, key := range keys {
+		cached := c.files[key]
+		if cached.Corrupted != nil {
+			index.Corrupted = append(index.Corrupted, *cached.Corrupted)
+			continue
+		}
+		index.Entries = append(index.Entries, *cached.Entry)
+	}
+	return index
+}
+
+// mainFilesForProgress returns the cached main files referencing a progress file
+func (c *IndexCache) mainFilesForProgress(progressKey string) []string {
+	mainFiles := make([]string, 0, 1)
+	for key, cached := range c.files {
+		if cached.ProgressFile == progressKey {
+			mainFiles = append(mainFiles, key)
+		}
+	}
+	return mainFiles
+}
+
+// statObject returns the object info for key, or false if it does not exist
+func statObject(ctx context.Context, storage Storage, key string) (ObjectInfo, bool, error) {
+	object, err := storage.Stat(ctx, key)
+	if errors.Is(err, ErrObjectNotFound) {
+		return ObjectInfo{}, false, nil
+	}
+	if err != nil {
+		return ObjectInfo{}, false, err
+	}
+	return object, true, nil
+}
+
+// isMainReportKey reports whether a storage key is a main report file
+func isMainReportKey(key string) bool {
+	return strings.HasSuffix(key, ".main.json")
+}
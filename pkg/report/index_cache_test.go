@@ -0,0 +1,63 @@
+package report
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexCacheIncrementalUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage := NewLocalStorage(dir)
+	cacheFile := filepath.Join(t.TempDir(), "cache.json")
+
+	for _, sub := range []string{"2025-01-01/hoodi", "2025-01-02/hoodi"} {
+		svc := newTestService(t)
+		require.NoError(t, svc.AddSyncProgressEntry(ctx, SyncProgressEntry{T: 1, Block: 1}))
+		require.NoError(t, svc.SaveReportToFiles(ctx, "hoodi_geth_teku", filepath.Join(dir, sub)))
+	}
+
+	log := logrus.New()
+	log.SetLevel(logrus.WarnLevel)
+
+	cache, err := NewIndexCache(cacheFile)
+	require.NoError(t, err)
+	indexService := NewIndexService(log)
+	indexService.SetCache(cache)
+
+	// Nested reports are indexed with paths relative to the storage root
+	index, err := indexService.GenerateIndexFromStorage(ctx, storage)
+	require.NoError(t, err)
+	require.Len(t, index.Entries, 2)
+	entry := index.Entries[0]
+	assert.Equal(t, "2025-01-01/hoodi", filepath.Dir(entry.MainFile))
+	assert.Equal(t, "2025-01-01/hoodi", filepath.Dir(entry.ProgressFile))
+
+	// A changed progress file invalidates the report referencing it
+	require.NoError(t, os.WriteFile(filepath.Join(dir, entry.ProgressFile), []byte(`[]`), 0o600))
+	index, err = indexService.UpdateIndex(ctx, storage, []string{entry.ProgressFile})
+	require.NoError(t, err)
+	require.Len(t, index.Entries, 1)
+	require.Len(t, index.Corrupted, 1)
+	assert.Equal(t, entry.MainFile, index.Corrupted[0].MainFile)
+
+	// Deleted reports are dropped
+	require.NoError(t, os.Remove(filepath.Join(dir, entry.MainFile)))
+	index, err = indexService.UpdateIndex(ctx, storage, []string{entry.MainFile})
+	require.NoError(t, err)
+	assert.Len(t, index.Entries, 1)
+	assert.Empty(t, index.Corrupted)
+
+	// The cache survives a restart
+	reloaded, err := NewIndexCache(cacheFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reloaded.Len())
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// fileMatcher selects report files by include and exclude glob patterns. Patterns use
+// path.Match syntax plus "**" for any number of directories. Patterns without a slash
+// match the file name in any directory.
+type fileMatcher struct {
+	include []string
+	exclude []string
+}
+
+// newFileMatcher validates the patterns and creates a matcher
+func newFileMatcher(include, exclude []string) (*fileMatcher, error) {
+	for _, pattern := range append(append([]string(nil), include...), exclude...) {
+		if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return &fileMatcher{include: include, exclude: exclude}, nil
+}
+
+// matches reports whether key is a main report file selected by the patterns
+func (m *fileMatcher) matches(key string) bool {
+	if !isMainReportKey(key) {
+		return false
+	}
+	if m == nil {
+		return true
+	}
+
+	for _, pattern := range m.exclude {
+		if matchGlob(pattern, key) {
+			return false
+		}
+	}
+
+	if len(m.include) == 0 {
+		return true
+	}
+	for _, pattern := range m.include {
+		if matchGlob(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches a slash-separated key against a glob pattern
+func matchGlob(pattern, key string) bool {
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(key))
+		return matched
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(key, "/"))
+}
+
+// matchSegments matches path segments, letting "**" consume zero or more segments
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := len(segments); i >= 0; i-- {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
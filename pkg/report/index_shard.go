@@ -0,0 +1,162 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidShardKey is returned when an index is sharded by an unsupported key
+var ErrInvalidShardKey = errors.New("invalid shard key")
+
+// Supported index shard keys
+const (
+	ShardByNone    = "none"
+	ShardByNetwork = "network"
+	ShardByClient  = "client" // network, EL and CL client
+)
+
+// IndexFilter selects index entries
+type IndexFilter struct {
+	Network string
+	Since   time.Time // Zero means no lower bound
+	Labels  map[string]string
+}
+
+// Matches reports whether an entry passes the filter
+func (f IndexFilter) Matches(entry *IndexEntry) bool {
+	if f.Network != "" && entry.Network != f.Network {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Timestamp < f.Since.Unix() {
+		return false
+	}
+	for key, value := range f.Labels {
+		if entry.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterIndex returns a copy of index containing only the entries matching filter
+func FilterIndex(index *Index, filter IndexFilter) *Index {
+	filtered := &Index{
+		Generated: index.Generated,
+		Entries:   make([]IndexEntry, 0, len(index.Entries)),
+		Corrupted: index.Corrupted,
+	}
+	for i := range index.Entries {
+		if filter.Matches(&index.Entries[i]) {
+			filtered.Entries = append(filtered.Entries, index.Entries[i])
+		}
+	}
+	return filtered
+}
+
+// IndexShard describes a shard file set listed in an index manifest
+type IndexShard struct {
+	Name         string   `json:"name"`
+	Network      string   `json:"network"`
+	ELClient     string   `json:"el_client,omitempty"`
+	CLClient     string   `json:"cl_client,omitempty"`
+	EntriesCount int      `json:"entries_count"`
+	Latest       int64    `json:"latest"` // Timestamp of the newest entry
+	Files        []string `json:"files"`  // Pages, newest entries first, relative to the manifest
+}
+
+// ShardIndex splits index into shard files grouped by shardBy. Each shard is split into
+// pages of pageSize entries (newest first) when pageSize > 0. It returns the manifest,
+// which has no entries of its own, and the shard pages keyed by path relative to the
+// manifest, placed under shardDir.
+func ShardIndex(index *Index, shardBy, shardDir string, pageSize int) (*Index, map[string]*Index, error) {
+	groups := make(map[string][]IndexEntry)
+	shards := make(map[string]*IndexShard)
+
+	for _, entry := range index.Entries {
+		var shard IndexShard
+		switch shardBy {
+		case ShardByNetwork:
+			shard = IndexShard{Name: entry.Network, Network: entry.Network}
+		case ShardByClient:
+			shard = IndexShard{
+				Name:     fmt.Sprintf("%s_%s_%s", entry.Network, entry.ExecutionClientInfo.Type, entry.ConsensusClientInfo.Type),
+				Network:  entry.Network,
+				ELClient: entry.ExecutionClientInfo.Type,
+				CLClient: entry.ConsensusClientInfo.Type,
+			}
+		default:
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidShardKey, shardBy)
+		}
+
+		shard.Name = sanitizeShardName(shard.Name)
+		if _, ok := shards[shard.Name]; !ok {
+			shards[shard.Name] = &shard
+		}
+		groups[shard.Name] = append(groups[shard.Name], entry)
+	}
+
+	manifest := &Index{
+		Generated:  index.Generated,
+		Entries:    make([]IndexEntry, 0),
+		Corrupted:  index.Corrupted,
+		Shards:     make([]IndexShard, 0, len(shards)),
+		Aggregates: index.Aggregates,
+	}
+	files := make(map[string]*Index)
+
+	for name, entries := range groups {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Timestamp > entries[j].Timestamp })
+
+		shard := shards[name]
+		shard.EntriesCount = len(entries)
+		shard.Latest = entries[0].Timestamp
+
+		pages := paginateEntries(entries, pageSize)
+		for i, page := range pages {
+			file := path.Join(shardDir, name+".json")
+			if len(pages) > 1 || pageSize > 0 {
+				file = path.Join(shardDir, fmt.Sprintf("%s-%d.json", name, i+1))
+			}
+			shard.Files = append(shard.Files, file)
+			files[file] = &Index{Generated: index.Generated, Entries: page}
+		}
+
+		manifest.Shards = append(manifest.Shards, *shard)
+	}
+
+	sort.Slice(manifest.Shards, func(i, j int) bool { return manifest.Shards[i].Name < manifest.Shards[j].Name })
+
+	return manifest, files, nil
+}
+
+// paginateEntries splits entries into pages of at most pageSize entries
+func paginateEntries(entries []IndexEntry, pageSize int) [][]IndexEntry {
+	if pageSize <= 0 || len(entries) <= pageSize {
+		return [][]IndexEntry{entries}
+	}
+
+	pages := make([][]IndexEntry, 0, (len(entries)+pageSize-1)/pageSize)
+	for start := 0; start < len(entries); start += pageSize {
+		pages = append(pages, entries[start:min(start+pageSize, len(entries))])
+	}
+	return pages
+}
+
+// sanitizeShardName keeps shard file names to a safe character set
+func sanitizeShardName(name string) string {
+	if name == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+}
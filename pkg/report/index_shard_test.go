@@ -0,0 +1,47 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterAndShardIndex(t *testing.T) {
+	t.Parallel()
+
+	entry := func(runID, network, el string, timestamp int64, labels map[string]string) IndexEntry {
+		e := IndexEntry{RunID: runID, Network: network, Timestamp: timestamp, Labels: labels}
+		e.ExecutionClientInfo.Type = el
+		e.ConsensusClientInfo.Type = "teku"
+		return e
+	}
+
+	index := &Index{Entries: []IndexEntry{
+		entry("a", "hoodi", "geth", 100, map[string]string{"ci": "true"}),
+		entry("b", "hoodi", "geth", 300, nil),
+		entry("c", "hoodi", "reth", 200, map[string]string{"ci": "true"}),
+		entry("d", "sepolia", "geth", 400, map[string]string{"ci": "true"}),
+	}}
+
+	filtered := FilterIndex(index, IndexFilter{Network: "hoodi", Since: time.Unix(150, 0)})
+	require.Len(t, filtered.Entries, 2)
+	assert.Len(t, FilterIndex(index, IndexFilter{Labels: map[string]string{"ci": "true"}}).Entries, 3)
+
+	manifest, files, err := ShardIndex(index, ShardByClient, "index", 1)
+	require.NoError(t, err)
+	assert.Empty(t, manifest.Entries)
+	require.Len(t, manifest.Shards, 3)
+
+	hoodiGeth := manifest.Shards[0]
+	assert.Equal(t, "hoodi_geth_teku", hoodiGeth.Name)
+	assert.Equal(t, 2, hoodiGeth.EntriesCount)
+	assert.Equal(t, int64(300), hoodiGeth.Latest)
+	require.Equal(t, []string{"index/hoodi_geth_teku-1.json", "index/hoodi_geth_teku-2.json"}, hoodiGeth.Files)
+	assert.Equal(t, "b", files[hoodiGeth.Files[0]].Entries[0].RunID)
+	assert.Len(t, files, 4)
+
+	_, _, err = ShardIndex(index, "region", "index", 0)
+	require.ErrorIs(t, err, ErrInvalidShardKey)
+}
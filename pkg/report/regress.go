@@ -0,0 +1,163 @@
+package report
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrNoMetrics is returned when a regression check has no metrics to evaluate
+var ErrNoMetrics = errors.New("no metrics selected")
+
+// DefaultRegressionMetrics are the metrics checked against historical baselines by default
+var DefaultRegressionMetrics = []string{"duration", "disk_el", "disk_cl", "peak_memory_el", "peak_memory_cl"}
+
+// RegressionOptions configures DetectRegressions
+type RegressionOptions struct {
+	Metrics          []string          // Metric names, see RunMetricDefinitions
+	Labels           map[string]string // Baseline runs must carry these labels
+	MinSamples       int               // Minimum baseline runs needed to flag a regression
+	IQRMultiplier    float64           // Values above Q3 + IQRMultiplier*IQR are regressions
+	MinChangePercent float64           // Values must also exceed the median by this much
+}
+
+// Baseline summarizes the historical distribution of a metric
+type Baseline struct {
+	Samples int     `json:"samples"`
+	Median  float64 `json:"median"`
+	Q1      float64 `json:"q1"`
+	Q3      float64 `json:"q3"`
+	IQR     float64 `json:"iqr"`
+}
+
+// RegressionCheck is the result of checking one metric against its baseline
+type RegressionCheck struct {
+	Name          string   `json:"name"`
+	Label         string   `json:"label"`
+	Unit          string   `json:"unit"`
+	Value         float64  `json:"value"`
+	Baseline      Baseline `json:"baseline"`
+	UpperFence    float64  `json:"upper_fence"`
+	ChangePercent float64  `json:"change_percent"` // Relative to the baseline median
+	Regression    bool     `json:"regression"`
+}
+
+// RegressionReport is the result of checking a run against prior runs of the same configuration
+type RegressionReport struct {
+	Report           ReportRef         `json:"report"`
+	BaselineRuns     []string          `json:"baseline_runs"`
+	InsufficientData bool              `json:"insufficient_data"`
+	Checks           []RegressionCheck `json:"checks"`
+	Regressions      []string          `json:"regressions"`
+}
+
+// IsBaselineCandidate reports whether other is a prior successful run of the same
+// network and client pair as candidate, carrying all of the given labels
+func IsBaselineCandidate(candidate, other *Result, labels map[string]string) bool {
+	if other.RunID == candidate.RunID || other.SyncStatus.Status != "success" {
+		return false
+	}
+	if other.Network != candidate.Network ||
+		other.ExecutionClientInfo.Type != candidate.ExecutionClientInfo.Type ||
+		other.ConsensusClientInfo.Type != candidate.ConsensusClientInfo.Type {
+		return false
+	}
+	for key, value := range labels {
+		if other.Labels[key] != value {
+			return false
+		}
+	}
+	return other.Timestamp <= candidate.Timestamp
+}
+
+// DetectRegressions checks candidate against a baseline built from the matching runs in
+// history. A metric regresses when it lies above the Tukey fence (Q3 + k*IQR) and exceeds
+// the median by at least MinChangePercent. Nothing is flagged with fewer than MinSamples runs.
+func DetectRegressions(candidate *Result, history []*Result, opts RegressionOptions) (*RegressionReport, error) {
+	if len(opts.Metrics) == 0 {
+		return nil, ErrNoMetrics
+	}
+
+	definitions := make([]MetricDefinition, 0, len(opts.Metrics))
+	for _, name := range opts.Metrics {
+		definition, err := LookupMetric(name)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	result := &RegressionReport{
+		Report:       newReportRef(candidate),
+		BaselineRuns: make([]string, 0),
+		Regressions:  make([]string, 0),
+	}
+
+	baselineMetrics := make([]RunMetrics, 0, len(history))
+	for _, other := range history {
+		if IsBaselineCandidate(candidate, other, opts.Labels) {
+			result.BaselineRuns = append(result.BaselineRuns, other.RunID)
+			baselineMetrics = append(baselineMetrics, ComputeMetrics(other))
+		}
+	}
+	result.InsufficientData = len(baselineMetrics) < max(opts.MinSamples, 1)
+
+	candidateMetrics := ComputeMetrics(candidate)
+	for _, definition := range definitions {
+		samples := make([]float64, 0, len(baselineMetrics))
+		for _, metrics := range baselineMetrics {
+			samples = append(samples, definition.Value(metrics))
+		}
+
+		check := RegressionCheck{
+			Name:     definition.Name,
+			Label:    definition.Label,
+			Unit:     definition.Unit,
+			Value:    definition.Value(candidateMetrics),
+			Baseline: NewBaseline(samples),
+		}
+		check.UpperFence = check.Baseline.Q3 + opts.IQRMultiplier*check.Baseline.IQR
+		if check.Baseline.Median != 0 {
+			check.ChangePercent = (check.Value - check.Baseline.Median) / check.Baseline.Median * 100
+		}
+		check.Regression = !result.InsufficientData &&
+			check.Value > check.UpperFence &&
+			check.ChangePercent >= opts.MinChangePercent
+
+		if check.Regression {
+			result.Regressions = append(result.Regressions, definition.Name)
+		}
+		result.Checks = append(result.Checks, check)
+	}
+
+	return result, nil
+}
+
+// NewBaseline computes the median and interquartile range of samples
+func NewBaseline(samples []float64) Baseline {
+	if len(samples) == 0 {
+		return Baseline{}
+	}
+
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	baseline := Baseline{
+		Samples: len(sorted),
+		Median:  quantile(sorted, 0.5),
+		Q1:      quantile(sorted, 0.25),
+		Q3:      quantile(sorted, 0.75),
+	}
+	baseline.IQR = baseline.Q3 - baseline.Q1
+	return baseline
+}
+
+// quantile returns the q-th quantile of sorted using linear interpolation
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(position)
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+	fraction := position - float64(lower)
+	return sorted[lower] + fraction*(sorted[lower+1]-sorted[lower])
+}
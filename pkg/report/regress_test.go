@@ -0,0 +1,69 @@
+package report
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBaseline(t *testing.T) {
+	t.Parallel()
+
+	baseline := NewBaseline([]float64{4, 1, 3, 2, 5})
+	assert.Equal(t, Baseline{Samples: 5, Median: 3, Q1: 2, Q3: 4, IQR: 2}, baseline)
+}
+
+func TestDetectRegressions(t *testing.T) {
+	t.Parallel()
+
+	history := make([]*Result, 0)
+	for i, duration := range []int64{1000, 1100, 950, 1050, 1000} {
+		run := &Result{RunID: fmt.Sprintf("run-%d", i), Timestamp: int64(i), Network: "hoodi", Labels: map[string]string{"arch": "amd64"}}
+		run.ExecutionClientInfo.Type = "geth"
+		run.ConsensusClientInfo.Type = "teku"
+		run.SyncStatus.Status = "success"
+		run.SyncStatus.Start = 1
+		run.SyncStatus.End = 1 + duration
+		history = append(history, run)
+	}
+
+	// A run with a different client pair must not be part of the baseline
+	other := *history[0]
+	other.RunID = "other"
+	other.ExecutionClientInfo.Type = "reth"
+	history = append(history, &other)
+
+	candidate := *history[0]
+	candidate.RunID = "candidate"
+	candidate.Timestamp = 100
+	candidate.SyncStatus.End = 1 + 1500
+
+	opts := RegressionOptions{
+		Metrics:          []string{"duration"},
+		Labels:           map[string]string{"arch": "amd64"},
+		MinSamples:       3,
+		IQRMultiplier:    1.5,
+		MinChangePercent: 5,
+	}
+
+	result, err := DetectRegressions(&candidate, history, opts)
+	require.NoError(t, err)
+	assert.Len(t, result.BaselineRuns, 5)
+	assert.Equal(t, []string{"duration"}, result.Regressions)
+
+	// Within the fence
+	candidate.SyncStatus.End = 1 + 1100
+	result, err = DetectRegressions(&candidate, history, opts)
+	require.NoError(t, err)
+	assert.Empty(t, result.Regressions)
+
+	// Too few matching runs
+	opts.Labels = map[string]string{"arch": "arm64"}
+	candidate.SyncStatus.End = 1 + 5000
+	result, err = DetectRegressions(&candidate, history, opts)
+	require.NoError(t, err)
+	assert.True(t, result.InsufficientData)
+	assert.Empty(t, result.Regressions)
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethpandaops/syncoor/pkg/sysinfo"
@@ -27,13 +29,15 @@ type Service interface {
 	SetNetwork(ctx context.Context, network string) error
 	SetSystemInfo(ctx context.Context, info *sysinfo.SystemInfo) error
 	SaveReportToFiles(ctx context.Context, baseFilename string, reportDir string) error
+	SetStorage(storage Storage)
 	Stop(ctx context.Context) error
 
 	// Temporary report methods for recovery
-	SaveTempReport(ctx context.Context, report *Result) error
-	LoadTempReport(ctx context.Context, network, elClient, clClient string) (*Result, error)
-	RemoveTempReport(ctx context.Context, network, elClient, clClient string) error
-	ListTempReports(ctx context.Context) ([]string, error)
+	SetStateDir(dir string)
+	SaveTempReport(ctx context.Context, key TempReportKey, report *Result) error
+	LoadTempReport(ctx context.Context, key TempReportKey) (*Result, error)
+	RemoveTempReport(ctx context.Context, key TempReportKey) error
+	ListTempReports(ctx context.Context) ([]TempReportInfo, error)
 
 	// Get current report state
 	GetCurrentReport(ctx context.Context) (*Result, error)
@@ -72,8 +76,11 @@ type SyncStatus struct {
 	Slot             uint64                 `json:"slot"`
 	SyncProgress     []SyncProgressEntry    `json:"sync_progress,omitempty"`
 	SyncProgressFile string                 `json:"sync_progress_file,omitempty"`
+	SyncProgressHash string                 `json:"sync_progress_checksum,omitempty"` // Checksum of the progress file ("sha256:<hex>")
+	ProgressSeries   []ProgressSeries       `json:"sync_progress_series,omitempty"`
 	LastEntry        *SyncProgressEntry     `json:"last_entry,omitempty"`
 	EntriesCount     int                    `json:"entries_count"`
+	EntriesDropped   int                    `json:"entries_dropped,omitempty"` // Oldest progress entries missing from the report
 	ErrorDetails     map[string]interface{} `json:"error_details,omitempty"`
 }
 
@@ -101,10 +108,21 @@ type SyncProgressEntry struct {
 	CPUUsagePercentConsensusClient float64 `json:"cc"`  // Consensus client CPU usage (percent)
 }
 
-// service implements the Service interface
+// service implements the Service interface. All access to result is guarded by mu
+// since the sync loop and signal handling update the report from different goroutines.
 type service struct {
 	log    logrus.FieldLogger
+	mu     sync.RWMutex
 	result *Result
+
+	// fileMu serializes writes to report files on disk
+	fileMu sync.Mutex
+
+	// storage receives report files when set, otherwise they are written to the report directory
+	storage Storage
+
+	// stateDir holds temporary reports used for recovery
+	stateDir string
 }
 
 // NewService creates a new report service
@@ -112,12 +130,16 @@ func NewService(log logrus.FieldLogger) Service {
 	r := &Result{}
 	r.SyncStatus.SyncProgress = make([]SyncProgressEntry, 0)
 	return &service{
-		log:    log.WithField("package", "report"),
-		result: r,
+		log:      log.WithField("package", "report"),
+		result:   r,
+		stateDir: DefaultStateDir,
 	}
 }
 
 func (s *service) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.result.RunID != "" {
 		return errors.New("report service already started")
 	}
@@ -131,18 +153,30 @@ func (s *service) Start(ctx context.Context) error {
 
 func (s *service) Stop(ctx context.Context) error {
 	s.log.Debug("Stopping report service")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.result.SyncStatus.End = time.Now().Unix()
 	return nil
 }
 
 func (s *service) SetBlockNumber(ctx context.Context, blockNumber uint64) error {
 	s.log.WithField("blockNumber", blockNumber).Debug("Setting block number")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.result.SyncStatus.Block = blockNumber
 	return nil
 }
 
 func (s *service) SetSlotNumber(ctx context.Context, slotNumber uint64) error {
 	s.log.WithField("slotNumber", slotNumber).Debug("Setting slot number")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.result.SyncStatus.Slot = slotNumber
 	return nil
 }
@@ -152,6 +186,10 @@ func (s *service) SetSyncStatus(ctx context.Context, status string, message stri
 		"status":  status,
 		"message": message,
 	}).Debug("Setting sync status")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.result.SyncStatus.Status = status
 	s.result.SyncStatus.StatusMessage = message
 
@@ -169,24 +207,40 @@ func (s *service) SetSyncStatus(ctx context.Context, status string, message stri
 
 func (s *service) SetLabels(ctx context.Context, labels map[string]string) error {
 	s.log.WithField("labels", labels).Debug("Setting labels")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.result.Labels = labels
 	return nil
 }
 
 func (s *service) SetNetwork(ctx context.Context, network string) error {
 	s.log.WithField("network", network).Debug("Setting network")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.result.Network = network
 	return nil
 }
 
 func (s *service) SetSystemInfo(ctx context.Context, info *sysinfo.SystemInfo) error {
 	s.log.WithField("system_info", info).Debug("Setting system info")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.result.SystemInfo = info
 	return nil
 }
 
 func (s *service) AddSyncProgressEntry(ctx context.Context, entry SyncProgressEntry) error {
 	s.log.WithField("entry", entry).Debug("Adding sync progress entry")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.result.SyncStatus.SyncProgress = append(s.result.SyncStatus.SyncProgress, entry)
 	s.result.SyncStatus.EntriesCount = len(s.result.SyncStatus.SyncProgress)
 	return nil
@@ -195,6 +249,9 @@ func (s *service) AddSyncProgressEntry(ctx context.Context, entry SyncProgressEn
 func (s *service) SetExecutionClientInfo(ctx context.Context, info *ClientInfo) error {
 	s.log.WithField("info", info).Debug("Setting execution client info")
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if info.Name != "" {
 		s.result.ExecutionClientInfo.Name = info.Name
 	}
@@ -223,6 +280,9 @@ func (s *service) SetExecutionClientInfo(ctx context.Context, info *ClientInfo)
 func (s *service) SetConsensusClientInfo(ctx context.Context, info *ClientInfo) error {
 	s.log.WithField("info", info).Debug("Setting consensus client info")
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if info.Name != "" {
 		s.result.ConsensusClientInfo.Name = info.Name
 	}
@@ -248,51 +308,130 @@ func (s *service) SetConsensusClientInfo(ctx context.Context, info *ClientInfo)
 	return nil
 }
 
+// SetStorage configures the backend report files are written to. When set, the
+// report directory passed to SaveReportToFiles is ignored.
+func (s *service) SetStorage(storage Storage) {
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	s.storage = storage
+}
+
 // GenerateReport generates a report from the sync test results
 func (s *service) SaveReportToFiles(ctx context.Context, baseFilename string, dir string) error {
+	// Work on a snapshot so the sync loop can keep updating the report while files are written
+	snapshot := s.snapshot()
 
-	fullFilePrefix := fmt.Sprintf("%s-%s", s.result.RunID, baseFilename)
-	mainFilePath := filepath.Join(dir, fullFilePrefix+".main.json")
-	progressFilePath := filepath.Join(dir, fullFilePrefix+".progress.json")
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
 
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create report directory: %w", err)
+	storage := s.storage
+	if storage == nil {
+		storage = NewLocalStorage(dir)
 	}
 
+	mainFileKey, err := WriteReport(ctx, storage, snapshot, baseFilename)
+	if err != nil {
+		return err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"filename": strings.TrimSuffix(mainFileKey, ".main.json"),
+		"storage":  storage.String(),
+	}).Info("Report generated successfully")
+
+	return nil
+}
+
+// WriteReport writes a report as "<run id>-<baseFilename>" main and progress files to
+// storage and returns the key of the main file
+func WriteReport(ctx context.Context, storage Storage, result *Result, baseFilename string) (string, error) {
+	fullFilePrefix := fmt.Sprintf("%s-%s", result.RunID, baseFilename)
+	mainFileKey := fullFilePrefix + ".main.json"
+	progressFileKey := fullFilePrefix + ".progress.json"
+
 	// Save sync progress to separate file
-	progressData, err := json.MarshalIndent(s.result.SyncStatus.SyncProgress, "", "  ")
+	progressData, err := json.MarshalIndent(result.SyncStatus.SyncProgress, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal sync progress: %w", err)
+		return "", fmt.Errorf("failed to marshal sync progress: %w", err)
+	}
+
+	// Progress files are written before the main file so a main file never references missing data
+	if err := storage.Write(ctx, progressFileKey, progressData); err != nil {
+		return "", fmt.Errorf("failed to write progress file: %w", err)
 	}
 
-	if err := os.WriteFile(progressFilePath, progressData, 0644); err != nil {
-		return fmt.Errorf("failed to write progress file: %w", err)
+	// Save downsampled progress series so long runs can be charted cheaply
+	progressSeries, err := saveDownsampledProgress(ctx, storage, result.SyncStatus.SyncProgress, progressData, fullFilePrefix)
+	if err != nil {
+		return "", err
 	}
 
-	// Create a copy of the report for the main file (without sync progress data)
-	mainReport := *s.result
-	mainReport.SyncStatus.SyncProgressFile = fullFilePrefix + ".progress.json"
+	// Copy the result for the main file (without sync progress data)
+	mainReport := *result
+	mainReport.SyncStatus.SyncProgressFile = progressFileKey
+	mainReport.SyncStatus.SyncProgressHash = checksum(progressData)
+	mainReport.SyncStatus.ProgressSeries = progressSeries
 
 	// Set the last entry if there are sync progress entries
-	if len(s.result.SyncStatus.SyncProgress) > 0 {
-		lastEntry := s.result.SyncStatus.SyncProgress[len(s.result.SyncStatus.SyncProgress)-1]
+	if len(result.SyncStatus.SyncProgress) > 0 {
+		lastEntry := result.SyncStatus.SyncProgress[len(result.SyncStatus.SyncProgress)-1]
 		mainReport.SyncStatus.LastEntry = &lastEntry
 	}
 
 	mainReport.SyncStatus.SyncProgress = nil // Remove the sync progress data from main report
 
-	jsonData, err := json.MarshalIndent(&mainReport, "", "  ")
+	jsonData, err := json.MarshalIndent(mainReport, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to export report as JSON: %w", err)
+		return "", fmt.Errorf("failed to export report as JSON: %w", err)
 	}
 
-	if err := os.WriteFile(mainFilePath, jsonData, 0644); err != nil {
-		return fmt.Errorf("failed to write report to file: %w", err)
+	if err := storage.Write(ctx, mainFileKey, jsonData); err != nil {
+		return "", fmt.Errorf("failed to write report to file: %w", err)
 	}
 
-	s.log.WithField("filename", fullFilePrefix).Info("Report generated successfully")
+	return mainFileKey, nil
+}
 
-	return nil
+// saveDownsampledProgress writes a progress file per configured resolution and
+// returns the series references, starting with the full resolution series
+func saveDownsampledProgress(
+	ctx context.Context, storage Storage, entries []SyncProgressEntry, fullData []byte, fullFilePrefix string,
+) ([]ProgressSeries, error) {
+	series := []ProgressSeries{{
+		Resolution:   "full",
+		File:         fullFilePrefix + ".progress.json",
+		EntriesCount: len(entries),
+		Checksum:     checksum(fullData),
+	}}
+
+	for _, resolution := range DefaultProgressResolutions {
+		downsampled := DownsampleProgress(entries, resolution.Interval)
+		if len(downsampled) >= len(entries) {
+			// Nothing to gain from a series that is as large as the full one
+			continue
+		}
+
+		filename := fmt.Sprintf("%s.progress.%s.json", fullFilePrefix, resolution.Name)
+		data, err := json.MarshalIndent(downsampled, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s progress series: %w", resolution.Name, err)
+		}
+
+		if err := storage.Write(ctx, filename, data); err != nil {
+			return nil, fmt.Errorf("failed to write %s progress file: %w", resolution.Name, err)
+		}
+
+		series = append(series, ProgressSeries{
+			Resolution:   resolution.Name,
+			Interval:     int64(resolution.Interval.Seconds()),
+			File:         filename,
+			EntriesCount: len(downsampled),
+			Checksum:     checksum(data),
+		})
+	}
+
+	return series, nil
 }
 
 // Index types and functions
@@ -308,6 +447,7 @@ type IndexEntry struct {
 	SyncInfo            IndexSyncInfo     `json:"sync_info"`
 	MainFile            string            `json:"main_file"`
 	ProgressFile        string            `json:"progress_file"`
+	ProgressSeries      []ProgressSeries  `json:"progress_series,omitempty"`
 }
 
 // IndexClientInfo represents client information in the index
@@ -331,21 +471,38 @@ type IndexSyncInfo struct {
 	LastEntry     *SyncProgressEntry `json:"last_entry,omitempty"`
 }
 
+// CorruptedReport describes a report pair that failed the integrity check
+type CorruptedReport struct {
+	MainFile     string `json:"main_file"`
+	ProgressFile string `json:"progress_file,omitempty"`
+	Reason       string `json:"reason"`
+}
+
 // Index represents the complete index structure
 type Index struct {
-	Generated int64        `json:"generated"`
-	Entries   []IndexEntry `json:"entries"`
+	Generated  int64             `json:"generated"`
+	Entries    []IndexEntry      `json:"entries"`
+	Corrupted  []CorruptedReport `json:"corrupted,omitempty"`
+	Shards     []IndexShard      `json:"shards,omitempty"` // Set on sharded index manifests, which have no entries of their own
+	Aggregates []IndexAggregate  `json:"aggregates,omitempty"`
 }
 
 // IndexService defines the interface for index operations
 type IndexService interface {
 	GenerateIndex(ctx context.Context, reportDir string) (*Index, error)
+	GenerateIndexFromStorage(ctx context.Context, storage Storage) (*Index, error)
 	SaveIndex(ctx context.Context, index *Index, outputPath string) error
+	SaveIndexToStorage(ctx context.Context, index *Index, storage Storage, key string) error
+	UpdateIndex(ctx context.Context, storage Storage, changedKeys []string) (*Index, error)
+	SetCache(cache *IndexCache)
+	SetFilePatterns(include, exclude []string) error
 }
 
 // indexService implements the IndexService interface
 type indexService struct {
-	log logrus.FieldLogger
+	log     logrus.FieldLogger
+	cache   *IndexCache
+	matcher *fileMatcher
 }
 
 // NewIndexService creates a new index service
@@ -357,30 +514,161 @@ func NewIndexService(log logrus.FieldLogger) IndexService {
 
 // GenerateIndex scans the report directory and generates an index
 func (s *indexService) GenerateIndex(ctx context.Context, reportDir string) (*Index, error) {
-	s.log.WithField("reportDir", reportDir).Debug("Generating index")
+	return s.GenerateIndexFromStorage(ctx, NewLocalStorage(reportDir))
+}
+
+// SetCache enables incremental index generation. Only reports whose files changed
+// since they were cached are re-read.
+func (s *indexService) SetCache(cache *IndexCache) {
+	s.cache = cache
+}
 
-	index := &Index{
-		Generated: time.Now().Unix(),
-		Entries:   make([]IndexEntry, 0),
+// SetFilePatterns limits the indexed main report files to those matching an include
+// pattern (all files if none are given) and no exclude pattern
+func (s *indexService) SetFilePatterns(include, exclude []string) error {
+	matcher, err := newFileMatcher(include, exclude)
+	if err != nil {
+		return err
 	}
+	s.matcher = matcher
+	return nil
+}
+
+// GenerateIndexFromStorage recursively scans a storage backend and generates an index
+func (s *indexService) GenerateIndexFromStorage(ctx context.Context, storage Storage) (*Index, error) {
+	s.log.WithField("storage", storage.String()).Debug("Generating index")
 
 	// Find all main report files
-	mainFiles, err := s.findMainReportFiles(reportDir)
+	objects, err := storage.List(ctx, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to find main report files: %w", err)
 	}
 
-	// Process each main file
-	for _, mainFile := range mainFiles {
-		entry, err := s.processMainFile(mainFile, reportDir)
+	stats := make(map[string]ObjectInfo, len(objects))
+	for _, object := range objects {
+		stats[object.Key] = object
+	}
+
+	cache := s.cache
+	if cache == nil {
+		cache = &IndexCache{files: make(map[string]*indexCacheEntry)}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	// Drop reports that no longer exist or are no longer selected
+	for key := range cache.files {
+		if _, ok := stats[key]; !ok || !s.matcher.matches(key) {
+			delete(cache.files, key)
+		}
+	}
+
+	// Process new and changed main files
+	processed := 0
+	for _, object := range objects {
+		if !s.matcher.matches(object.Key) {
+			continue
+		}
+		if cached, ok := cache.files[object.Key]; ok {
+			progress, hasProgress := stats[cached.ProgressFile]
+			if cached.fresh(object, progress, hasProgress) {
+				continue
+			}
+		}
+		cache.files[object.Key] = s.processCacheEntry(ctx, storage, object, func(key string) (ObjectInfo, bool, error) {
+			info, ok := stats[key]
+			return info, ok, nil
+		})
+		processed++
+	}
+
+	return s.indexFromCache(cache, processed)
+}
+
+// UpdateIndex regenerates the index after the given keys changed, re-reading only the
+// affected reports. Keys may be main or progress files; missing main files are removed.
+// Without a cache the whole storage backend is scanned.
+func (s *indexService) UpdateIndex(ctx context.Context, storage Storage, changedKeys []string) (*Index, error) {
+	if s.cache == nil {
+		return s.GenerateIndexFromStorage(ctx, storage)
+	}
+
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+
+	stat := func(key string) (ObjectInfo, bool, error) {
+		return statObject(ctx, storage, key)
+	}
+
+	// Progress file changes invalidate the main files referencing them
+	mainFiles := make(map[string]struct{}, len(changedKeys))
+	for _, key := range changedKeys {
+		if isMainReportKey(key) {
+			if s.matcher.matches(key) {
+				mainFiles[key] = struct{}{}
+			}
+			continue
+		}
+		for _, mainFile := range s.cache.mainFilesForProgress(key) {
+			mainFiles[mainFile] = struct{}{}
+		}
+	}
+
+	for key := range mainFiles {
+		object, ok, err := stat(key)
 		if err != nil {
-			s.log.WithField("file", mainFile).WithError(err).Warn("Failed to process main file")
+			return nil, fmt.Errorf("failed to stat %s: %w", key, err)
+		}
+		if !ok {
+			delete(s.cache.files, key)
 			continue
 		}
-		index.Entries = append(index.Entries, *entry)
+		s.cache.files[key] = s.processCacheEntry(ctx, storage, object, stat)
+	}
+
+	return s.indexFromCache(s.cache, len(mainFiles))
+}
+
+// processCacheEntry processes a main report file into a cache entry
+func (s *indexService) processCacheEntry(
+	ctx context.Context, storage Storage, object ObjectInfo, stat func(key string) (ObjectInfo, bool, error),
+) *indexCacheEntry {
+	cached := &indexCacheEntry{Size: object.Size, ModTime: object.ModTime.UnixNano()}
+
+	entry, err := s.processMainFile(ctx, storage, object.Key)
+	if entry != nil && entry.ProgressFile != "" {
+		cached.ProgressFile = entry.ProgressFile
+		if progress, ok, statErr := stat(entry.ProgressFile); statErr == nil && ok {
+			cached.ProgressSize = progress.Size
+			cached.ProgressModTime = progress.ModTime.UnixNano()
+		}
+	}
+
+	if err != nil {
+		s.log.WithField("file", object.Key).WithError(err).Warn("Report failed integrity check")
+		corrupted := newCorruptedReport(object.Key, entry, err)
+		cached.Corrupted = &corrupted
+		return cached
+	}
+
+	cached.Entry = entry
+	return cached
+}
+
+// indexFromCache builds the index from a locked cache and persists the cache
+func (s *indexService) indexFromCache(cache *IndexCache, processed int) (*Index, error) {
+	index := cache.index(time.Now().Unix())
+
+	if err := cache.save(); err != nil {
+		s.log.WithError(err).Warn("Failed to save index cache")
 	}
 
-	s.log.WithField("entriesCount", len(index.Entries)).Info("Index generated successfully")
+	s.log.WithFields(logrus.Fields{
+		"entriesCount":   len(index.Entries),
+		"corruptedCount": len(index.Corrupted),
+		"processed":      processed,
+	}).Info("Index generated successfully")
 	return index, nil
 }
 
@@ -400,7 +688,7 @@ func (s *indexService) SaveIndex(ctx context.Context, index *Index, outputPath s
 	}
 
 	// Write to file
-	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+	if err := WriteFileAtomic(outputPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write index file: %w", err)
 	}
 
@@ -408,20 +696,28 @@ func (s *indexService) SaveIndex(ctx context.Context, index *Index, outputPath s
 	return nil
 }
 
-// findMainReportFiles finds all main report files in the directory
-func (s *indexService) findMainReportFiles(reportDir string) ([]string, error) {
-	pattern := filepath.Join(reportDir, "*.main.json")
-	files, err := filepath.Glob(pattern)
+// SaveIndexToStorage saves the index under key in a storage backend
+func (s *indexService) SaveIndexToStorage(ctx context.Context, index *Index, storage Storage, key string) error {
+	data, err := json.MarshalIndent(index, "", "  ")
 	if err != nil {
-		return nil, fmt.Errorf("failed to glob files: %w", err)
+		return fmt.Errorf("failed to marshal index: %w", err)
 	}
-	return files, nil
+
+	if err := storage.Write(ctx, key, data); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"storage": storage.String(),
+		"key":     key,
+	}).Info("Index saved successfully")
+	return nil
 }
 
 // processMainFile processes a main report file and extracts index information
-func (s *indexService) processMainFile(mainFilePath, reportDir string) (*IndexEntry, error) {
+func (s *indexService) processMainFile(ctx context.Context, storage Storage, mainFileKey string) (*IndexEntry, error) {
 	// Read the main file
-	data, err := os.ReadFile(mainFilePath)
+	data, err := storage.Read(ctx, mainFileKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read main file: %w", err)
 	}
@@ -470,166 +766,90 @@ func (s *indexService) processMainFile(mainFilePath, reportDir string) (*IndexEn
 			EntriesCount:  entriesCount,
 			LastEntry:     result.SyncStatus.LastEntry,
 		},
-		MainFile:     filepath.Base(mainFilePath),
-		ProgressFile: result.SyncStatus.SyncProgressFile,
-	}
-
-	return entry, nil
-}
-
-// SaveTempReport saves a temporary report to disk for recovery purposes
-func (s *service) SaveTempReport(ctx context.Context, report *Result) error {
-	if report == nil {
-		return fmt.Errorf("report cannot be nil")
+		MainFile:       mainFileKey,
+		ProgressFile:   relativeReportKey(mainFileKey, result.SyncStatus.SyncProgressFile),
+		ProgressSeries: make([]ProgressSeries, 0, len(result.SyncStatus.ProgressSeries)),
 	}
 
-	// Generate temp report filename from report data
-	tempFilename := s.generateTempReportFilename(report)
-	tempFilePath := filepath.Join("./reports", tempFilename)
-
-	s.log.WithField("temp_file", tempFilePath).Debug("Saving temporary report")
-
-	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(tempFilePath), 0755); err != nil {
-		return fmt.Errorf("failed to create temp report directory: %w", err)
+	// File references in a report are relative to its main file, index paths to the storage root
+	for _, series := range result.SyncStatus.ProgressSeries {
+		series.File = relativeReportKey(mainFileKey, series.File)
+		entry.ProgressSeries = append(entry.ProgressSeries, series)
 	}
 
-	// Marshal report to JSON
-	data, err := json.MarshalIndent(report, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal temp report: %w", err)
+	// Verify the referenced progress file; the entry is still returned so the caller can report it
+	if err := verifyProgressFile(ctx, storage, path.Dir(mainFileKey), &result.SyncStatus); err != nil {
+		return entry, err
 	}
 
-	// Write to file
-	if err := os.WriteFile(tempFilePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp report: %w", err)
-	}
-
-	s.log.WithField("temp_file", tempFilePath).Info("Temporary report saved successfully")
-	return nil
+	return entry, nil
 }
 
-// LoadTempReport loads a temporary report that matches the given configuration
-func (s *service) LoadTempReport(ctx context.Context, network, elClient, clClient string) (*Result, error) {
-	reportDir := "./reports"
-
-	// Find temp reports matching the configuration
-	tempFiles, err := s.findTempReports(network, elClient, clClient, reportDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find temp reports: %w", err)
-	}
-
-	if len(tempFiles) == 0 {
-		s.log.WithFields(logrus.Fields{
-			"network":   network,
-			"el_client": elClient,
-			"cl_client": clClient,
-		}).Debug("No temporary reports found")
-		return nil, nil
-	}
-
-	// Load the most recent temp report
-	tempFilePath := tempFiles[0]
-	s.log.WithField("temp_file", tempFilePath).Debug("Loading temporary report")
-
-	data, err := os.ReadFile(tempFilePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read temp report: %w", err)
-	}
-
-	var result Result
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal temp report: %w", err)
+// verifyProgressFile checks that the progress file referenced by a main report exists
+// and matches its recorded checksum. Reports written before checksums were introduced
+// are only checked for valid JSON.
+func verifyProgressFile(ctx context.Context, storage Storage, dir string, status *SyncStatus) error {
+	if status.SyncProgressFile == "" {
+		return nil
 	}
 
-	s.log.WithField("temp_file", tempFilePath).Info("Temporary report loaded successfully")
-	return &result, nil
-}
-
-// RemoveTempReport removes temporary reports that match the given configuration
-func (s *service) RemoveTempReport(ctx context.Context, network, elClient, clClient string) error {
-	reportDir := "./reports"
-
-	// Find temp reports matching the configuration
-	tempFiles, err := s.findTempReports(network, elClient, clClient, reportDir)
+	data, err := storage.Read(ctx, path.Join(dir, status.SyncProgressFile))
 	if err != nil {
-		return fmt.Errorf("failed to find temp reports: %w", err)
+		return fmt.Errorf("failed to read progress file: %w", err)
 	}
 
-	// Remove all matching temp files
-	for _, tempFile := range tempFiles {
-		if err := os.Remove(tempFile); err != nil {
-			s.log.WithField("temp_file", tempFile).WithError(err).Warn("Failed to remove temp report")
-		} else {
-			s.log.WithField("temp_file", tempFile).Debug("Removed temporary report")
+	if status.SyncProgressHash != "" {
+		if actual := checksum(data); actual != status.SyncProgressHash {
+			return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, status.SyncProgressHash, actual)
 		}
+		return nil
 	}
 
-	s.log.WithField("removed_count", len(tempFiles)).Info("Temporary reports cleaned up")
-	return nil
-}
-
-// ListTempReports lists all temporary report files
-func (s *service) ListTempReports(ctx context.Context) ([]string, error) {
-	reportDir := "./reports"
-	pattern := filepath.Join(reportDir, "*.tmp.json")
-
-	files, err := filepath.Glob(pattern)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list temp reports: %w", err)
+	if !json.Valid(data) {
+		return ErrInvalidProgressFile
 	}
 
-	s.log.WithField("temp_reports_count", len(files)).Debug("Listed temporary reports")
-	return files, nil
+	return nil
 }
 
-// generateTempReportFilename generates a temporary report filename from report data
-func (s *service) generateTempReportFilename(report *Result) string {
-	// Extract client info from report
-	network := report.Network
-	elClient := report.ExecutionClientInfo.Type
-	clClient := report.ConsensusClientInfo.Type
-
-	// Use configuration-based naming (not RunID) for consistent temp file names
-	if elClient == "" || clClient == "" {
-		return "sync-temp.tmp.json"
+// relativeReportKey resolves a file referenced by a main report to a storage key
+func relativeReportKey(mainFileKey, file string) string {
+	if file == "" {
+		return ""
 	}
-
-	return fmt.Sprintf("sync-temp-%s_%s_%s.tmp.json", network, elClient, clClient)
+	return path.Join(path.Dir(mainFileKey), file)
 }
 
-// findTempReports finds temporary reports matching the given configuration
-func (s *service) findTempReports(network, elClient, clClient, reportDir string) ([]string, error) {
-	// Pattern: sync-temp-{network}_{elclient}_{clclient}.tmp.json
-	pattern := fmt.Sprintf("sync-temp-%s_%s_%s.tmp.json", network, elClient, clClient)
-	globPattern := filepath.Join(reportDir, pattern)
-
-	files, err := filepath.Glob(globPattern)
-	if err != nil {
-		return nil, fmt.Errorf("failed to glob temp reports: %w", err)
+// newCorruptedReport builds the index record for a report pair that failed processing
+func newCorruptedReport(mainFileKey string, entry *IndexEntry, err error) CorruptedReport {
+	corrupted := CorruptedReport{
+		MainFile: mainFileKey,
+		Reason:   err.Error(),
 	}
-
-	// Sort by modification time (newest first)
-	if len(files) > 1 {
-		// Simple sorting by filename (contains timestamp)
-		for i := 0; i < len(files)-1; i++ {
-			for j := i + 1; j < len(files); j++ {
-				if strings.Compare(files[i], files[j]) < 0 {
-					files[i], files[j] = files[j], files[i]
-				}
-			}
-		}
+	if entry != nil {
+		corrupted.ProgressFile = entry.ProgressFile
 	}
-
-	return files, nil
+	return corrupted
 }
 
 // GetCurrentReport returns a copy of the current report state
 func (s *service) GetCurrentReport(ctx context.Context) (*Result, error) {
-	if s.result == nil {
+	s.mu.RLock()
+	started := s.result != nil
+	s.mu.RUnlock()
+
+	if !started {
 		return nil, fmt.Errorf("report service not started")
 	}
 
+	return s.snapshot(), nil
+}
+
+// snapshot returns a deep copy of the current report state
+func (s *service) snapshot() *Result {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	// Create a deep copy of the current report
 	reportCopy := &Result{
 		RunID:     s.result.RunID,
@@ -644,6 +864,7 @@ func (s *service) GetCurrentReport(ctx context.Context) (*Result, error) {
 			Block:         s.result.SyncStatus.Block,
 			Slot:          s.result.SyncStatus.Slot,
 			SyncProgress:  make([]SyncProgressEntry, len(s.result.SyncStatus.SyncProgress)),
+			EntriesCount:  s.result.SyncStatus.EntriesCount,
 			ErrorDetails:  make(map[string]interface{}),
 		},
 		ExecutionClientInfo: s.result.ExecutionClientInfo,
@@ -664,11 +885,14 @@ func (s *service) GetCurrentReport(ctx context.Context) (*Result, error) {
 		reportCopy.SyncStatus.ErrorDetails[k] = v
 	}
 
-	return reportCopy, nil
+	return reportCopy
 }
 
 // RestoreReportState restores the report state from recovered data
 func (s *service) RestoreReportState(ctx context.Context, restoredReport *Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.result == nil {
 		return fmt.Errorf("report service not started")
 	}
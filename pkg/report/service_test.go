@@ -0,0 +1,222 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T) Service {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetLevel(logrus.WarnLevel)
+
+	svc := NewService(log)
+	require.NoError(t, svc.Start(context.Background()))
+	require.NoError(t, svc.SetNetwork(context.Background(), "hoodi"))
+	require.NoError(t, svc.SetExecutionClientInfo(context.Background(), &ClientInfo{Type: "geth"}))
+	require.NoError(t, svc.SetConsensusClientInfo(context.Background(), &ClientInfo{Type: "teku"}))
+
+	return svc
+}
+
+func TestServiceConcurrentUpdatesAndSaves(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t)
+	dir := t.TempDir()
+
+	const entries = 200
+
+	var wg sync.WaitGroup
+
+	// Sync loop: progress updates
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range entries {
+			assert.NoError(t, svc.SetBlockNumber(ctx, uint64(i)))
+			assert.NoError(t, svc.SetSlotNumber(ctx, uint64(i)))
+			assert.NoError(t, svc.AddSyncProgressEntry(ctx, SyncProgressEntry{T: int64(i), Block: uint64(i)}))
+		}
+	}()
+
+	// Readers and writers racing with the sync loop
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			assert.NoError(t, svc.SaveReportToFiles(ctx, "hoodi_geth_teku", dir))
+			current, err := svc.GetCurrentReport(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, current.SyncStatus.SyncProgress, current.SyncStatus.EntriesCount)
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			assert.NoError(t, svc.SetSyncStatus(ctx, "running", "Sync test in progress"))
+			assert.NoError(t, svc.SetLabels(ctx, map[string]string{"k": "v"}))
+		}
+	}()
+
+	wg.Wait()
+
+	require.NoError(t, svc.Stop(ctx))
+	require.NoError(t, svc.SaveReportToFiles(ctx, "hoodi_geth_teku", dir))
+
+	current, err := svc.GetCurrentReport(ctx)
+	require.NoError(t, err)
+	assert.Len(t, current.SyncStatus.SyncProgress, entries)
+	assert.Equal(t, entries, current.SyncStatus.EntriesCount)
+
+	mainFiles, err := filepath.Glob(filepath.Join(dir, "*.main.json"))
+	require.NoError(t, err)
+	require.Len(t, mainFiles, 1)
+
+	data, err := os.ReadFile(mainFiles[0])
+	require.NoError(t, err)
+
+	var saved Result
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, entries, saved.SyncStatus.EntriesCount)
+	assert.Equal(t, uint64(entries-1), saved.SyncStatus.LastEntry.Block)
+}
+
+func TestServiceSignalShutdownDuringSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newTestService(t)
+	svc.SetStateDir(t.TempDir())
+
+	key := TempReportKey{Network: "hoodi", ELClient: "geth", CLClient: "teku", Enclave: "sync-test-hoodi-geth-teku", RunID: "run-1"}
+
+	var wg sync.WaitGroup
+
+	// Sync loop keeps adding entries and periodically saving temp reports
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			assert.NoError(t, svc.AddSyncProgressEntry(ctx, SyncProgressEntry{T: int64(i), Block: uint64(i)}))
+			if i%10 == 0 {
+				current, err := svc.GetCurrentReport(ctx)
+				assert.NoError(t, err)
+				assert.NoError(t, svc.SaveTempReport(ctx, key, current))
+			}
+		}
+	}()
+
+	// Signal handler marks the run as cancelled and saves a temp report
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, svc.SetSyncStatus(ctx, "cancelled", "Sync operation cancelled by interrupt signal"))
+		current, err := svc.GetCurrentReport(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, svc.SaveTempReport(ctx, key, current))
+		assert.NoError(t, svc.Stop(ctx))
+	}()
+
+	wg.Wait()
+
+	loaded, err := svc.LoadTempReport(ctx, key)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, "hoodi", loaded.Network)
+}
+
+func TestTempReportsAreScopedByEnclaveAndRun(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	stateDir := t.TempDir()
+	svc := newTestService(t)
+	svc.SetStateDir(stateDir)
+
+	pair := TempReportKey{Network: "hoodi", ELClient: "geth", CLClient: "teku"}
+	keyA, keyB := pair, pair
+	keyA.Enclave, keyA.RunID = "enclave-a", "sync-test-1"
+	keyB.Enclave, keyB.RunID = "enclave-b", "sync-test-2"
+
+	require.NoError(t, svc.SaveTempReport(ctx, keyA, &Result{RunID: "sync-test-1"}))
+	require.NoError(t, svc.SaveTempReport(ctx, keyB, &Result{RunID: "sync-test-2"}))
+
+	// Files written before enclave names were recorded are still listed and loadable
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, "sync-temp-hoodi_reth_prysm.tmp.json"), []byte(`{"run_id":"legacy"}`), 0o600))
+
+	reports, err := svc.ListTempReports(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+
+	loaded, err := svc.LoadTempReport(ctx, TempReportKey{Network: "hoodi", ELClient: "geth", CLClient: "teku", Enclave: "enclave-a"})
+	require.NoError(t, err)
+	assert.Equal(t, "sync-test-1", loaded.RunID)
+
+	loaded, err = svc.LoadTempReport(ctx, TempReportKey{Network: "hoodi", ELClient: "reth", CLClient: "prysm", Enclave: "any"})
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", loaded.RunID)
+
+	require.NoError(t, svc.RemoveTempReport(ctx, keyA))
+	reports, err = svc.ListTempReports(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	for _, report := range reports {
+		assert.NotEqual(t, "enclave-a", report.Enclave)
+	}
+}
+
+func TestIndexFlagsCorruptedReports(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	for _, pair := range []string{"hoodi_geth_teku", "hoodi_reth_prysm"} {
+		svc := newTestService(t)
+		require.NoError(t, svc.AddSyncProgressEntry(ctx, SyncProgressEntry{T: 1, Block: 1}))
+		require.NoError(t, svc.SaveReportToFiles(ctx, pair, dir))
+	}
+
+	// Truncate one progress file and break one main file
+	progressFiles, err := filepath.Glob(filepath.Join(dir, "*hoodi_geth_teku.progress.json"))
+	require.NoError(t, err)
+	require.Len(t, progressFiles, 1)
+	require.NoError(t, os.WriteFile(progressFiles[0], []byte(`[{"t":1`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.main.json"), []byte(`{"run_id":`), 0o600))
+
+	index, err := NewIndexService(logrus.New()).GenerateIndex(ctx, dir)
+	require.NoError(t, err)
+
+	require.Len(t, index.Entries, 1)
+	assert.Contains(t, index.Entries[0].MainFile, "hoodi_reth_prysm")
+	require.Len(t, index.Corrupted, 2)
+
+	reasons := map[string]string{}
+	for _, corrupted := range index.Corrupted {
+		reasons[corrupted.MainFile] = corrupted.Reason
+	}
+	assert.Contains(t, reasons["broken.main.json"], "failed to unmarshal main file")
+	for mainFile, reason := range reasons {
+		if mainFile != "broken.main.json" {
+			assert.Contains(t, reason, ErrChecksumMismatch.Error())
+		}
+	}
+
+	// No temp files are left behind by atomic writes
+	leftovers, err := filepath.Glob(filepath.Join(dir, ".*.tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
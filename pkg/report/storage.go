@@ -0,0 +1,178 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Storage errors
+var (
+	ErrObjectNotFound = errors.New("object not found")
+	ErrInvalidKey     = errors.New("invalid object key")
+)
+
+// Storage is a key/value backend for report files. Keys use forward slashes
+// regardless of the underlying backend.
+type Storage interface {
+	Write(ctx context.Context, key string, data []byte) error
+	Read(ctx context.Context, key string) ([]byte, error)
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	String() string
+}
+
+// ObjectInfo describes a stored report file
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// localStorage stores report files in a directory on the local filesystem
+type localStorage struct {
+	root string
+}
+
+// NewLocalStorage creates a storage backend rooted at the given directory
+func NewLocalStorage(root string) Storage {
+	return &localStorage{root: root}
+}
+
+func (l *localStorage) String() string {
+	return l.root
+}
+
+// Write atomically writes data under key, creating parent directories as needed
+func (l *localStorage) Write(ctx context.Context, key string, data []byte) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	return WriteFileAtomic(path, data, 0644)
+}
+
+func (l *localStorage) Read(ctx context.Context, key string) ([]byte, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+
+	return data, err
+}
+
+// List returns all files below the root whose key starts with prefix, sorted by key
+func (l *localStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := make([]ObjectInfo, 0)
+
+	// Only walk the directory containing the prefix so single-file lookups stay cheap
+	start := l.root
+	if i := strings.LastIndex(prefix, "/"); i > 0 && filepath.IsLocal(filepath.FromSlash(prefix[:i])) {
+		start = filepath.Join(l.root, filepath.FromSlash(prefix[:i]))
+	}
+
+	err := filepath.WalkDir(start, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == start {
+				return filepath.SkipDir
+			}
+			return err
+		}
+
+		// Skip hidden files such as in-flight atomic writes
+		if strings.HasPrefix(d.Name(), ".") && path != l.root {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.root, path)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", l.root, err)
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Stat returns the info of the file stored under key
+func (l *localStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return ObjectInfo{}, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+func (l *localStorage) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// path resolves a key to a filesystem path, rejecting keys that escape the root
+func (l *localStorage) path(key string) (string, error) {
+	localKey := filepath.FromSlash(key)
+	if key == "" || !filepath.IsLocal(localKey) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(l.root, localKey), nil
+}
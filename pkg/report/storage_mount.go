@@ -0,0 +1,124 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StorageMount exposes a storage backend under a key prefix
+type StorageMount struct {
+	Prefix  string // Without trailing slash, empty for the root
+	Storage Storage
+}
+
+// mountedStorage combines several storage backends into a single key space
+type mountedStorage struct {
+	mounts []StorageMount
+}
+
+// NewMountedStorage combines storage backends, each under its own key prefix. Keys are
+// routed to the mount with the longest matching prefix.
+func NewMountedStorage(mounts ...StorageMount) Storage {
+	sorted := append([]StorageMount(nil), mounts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i].Prefix) > len(sorted[j].Prefix) })
+	return &mountedStorage{mounts: sorted}
+}
+
+func (m *mountedStorage) String() string {
+	names := make([]string, 0, len(m.mounts))
+	for _, mount := range m.mounts {
+		names = append(names, mount.Storage.String())
+	}
+	return strings.Join(names, ",")
+}
+
+func (m *mountedStorage) Write(ctx context.Context, key string, data []byte) error {
+	mount, rel, err := m.route(key)
+	if err != nil {
+		return err
+	}
+	return mount.Storage.Write(ctx, rel, data)
+}
+
+func (m *mountedStorage) Read(ctx context.Context, key string) ([]byte, error) {
+	mount, rel, err := m.route(key)
+	if err != nil {
+		return nil, err
+	}
+	return mount.Storage.Read(ctx, rel)
+}
+
+func (m *mountedStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	mount, rel, err := m.route(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := mount.Storage.Stat(ctx, rel)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info.Key = key
+	return info, nil
+}
+
+func (m *mountedStorage) Delete(ctx context.Context, key string) error {
+	mount, rel, err := m.route(key)
+	if err != nil {
+		return err
+	}
+	return mount.Storage.Delete(ctx, rel)
+}
+
+// List returns the objects of all mounts whose keys start with prefix, sorted by key.
+// Objects shadowed by a mount with a longer prefix are skipped.
+func (m *mountedStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := make([]ObjectInfo, 0)
+
+	for i, mount := range m.mounts {
+		mountPrefix := mountKey(mount.Prefix, "")
+		innerPrefix := ""
+		switch {
+		case strings.HasPrefix(prefix, mountPrefix):
+			innerPrefix = strings.TrimPrefix(prefix, mountPrefix)
+		case !strings.HasPrefix(mountPrefix, prefix):
+			continue
+		}
+
+		listed, err := mount.Storage.List(ctx, innerPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range listed {
+			object.Key = mountKey(mount.Prefix, object.Key)
+			if owner, _, err := m.route(object.Key); err != nil || owner != &m.mounts[i] {
+				continue
+			}
+			objects = append(objects, object)
+		}
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// route returns the mount serving key and the key relative to that mount
+func (m *mountedStorage) route(key string) (*StorageMount, string, error) {
+	for i := range m.mounts {
+		mountPrefix := mountKey(m.mounts[i].Prefix, "")
+		if strings.HasPrefix(key, mountPrefix) {
+			return &m.mounts[i], strings.TrimPrefix(key, mountPrefix), nil
+		}
+	}
+	return nil, "", fmt.Errorf("%w: %q is not below any report directory", ErrInvalidKey, key)
+}
+
+// mountKey joins a mount prefix and a key
+func mountKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
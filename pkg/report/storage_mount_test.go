@@ -0,0 +1,59 @@
+package report
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexMountedStorageWithPatterns(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	root := t.TempDir()
+
+	for _, sub := range []string{"ci/2025-01-01/hoodi", "ci/2025-01-02/sepolia", "nightly/hoodi", "nightly/hoodi/scratch"} {
+		svc := newTestService(t)
+		require.NoError(t, svc.AddSyncProgressEntry(ctx, SyncProgressEntry{T: 1, Block: 1}))
+		require.NoError(t, svc.SaveReportToFiles(ctx, "hoodi_geth_teku", filepath.Join(root, sub)))
+	}
+
+	storage := NewMountedStorage(
+		StorageMount{Prefix: "ci", Storage: NewLocalStorage(filepath.Join(root, "ci"))},
+		StorageMount{Prefix: "nightly", Storage: NewLocalStorage(filepath.Join(root, "nightly"))},
+	)
+
+	log := logrus.New()
+	log.SetLevel(logrus.WarnLevel)
+	indexService := NewIndexService(log)
+	require.NoError(t, indexService.SetFilePatterns([]string{"ci/**/hoodi/*", "nightly/**"}, []string{"**/scratch/**"}))
+
+	index, err := indexService.GenerateIndexFromStorage(ctx, storage)
+	require.NoError(t, err)
+	require.Len(t, index.Entries, 2)
+	assert.Empty(t, index.Corrupted)
+	assert.Equal(t, "ci/2025-01-01/hoodi", filepath.Dir(index.Entries[0].MainFile))
+	assert.Equal(t, "nightly/hoodi", filepath.Dir(index.Entries[1].ProgressFile))
+
+	info, err := storage.Stat(ctx, index.Entries[1].MainFile)
+	require.NoError(t, err)
+	assert.Equal(t, index.Entries[1].MainFile, info.Key)
+	_, err = storage.Stat(ctx, "other/hoodi.main.json")
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	require.Error(t, indexService.SetFilePatterns([]string{"["}, nil))
+}
+
+func TestMatchGlob(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, matchGlob("*.main.json", "a/b/x.main.json"))
+	assert.True(t, matchGlob("**/x.main.json", "x.main.json"))
+	assert.True(t, matchGlob("a/**/x.main.json", "a/b/c/x.main.json"))
+	assert.False(t, matchGlob("a/*/x.main.json", "a/b/c/x.main.json"))
+	assert.False(t, matchGlob("b/**", "a/b/x.main.json"))
+}
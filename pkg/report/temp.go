@@ -0,0 +1,270 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultStateDir is where temporary reports are kept when no state directory is configured
+const DefaultStateDir = "./reports"
+
+const (
+	tempReportPrefix = "sync-temp-"
+	tempReportSuffix = ".tmp.json"
+)
+
+// TempReportKey identifies the run a temporary report belongs to. Temp files are named
+// sync-temp-{network}_{el}_{cl}.{enclave}.{run_id}.tmp.json so concurrent runs of the
+// same client pair on one host don't overwrite each other.
+type TempReportKey struct {
+	Network  string
+	ELClient string
+	CLClient string
+	Enclave  string
+	RunID    string
+}
+
+// TempReportInfo describes a temporary report in the state directory
+type TempReportInfo struct {
+	TempReportKey
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// SetStateDir sets the directory temporary reports are stored in
+func (s *service) SetStateDir(dir string) {
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	s.stateDir = dir
+}
+
+// SaveTempReport saves a temporary report to disk for recovery purposes
+func (s *service) SaveTempReport(ctx context.Context, key TempReportKey, report *Result) error {
+	if report == nil {
+		return fmt.Errorf("report cannot be nil")
+	}
+
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	tempFilePath := filepath.Join(s.stateDir, tempReportFilename(key))
+
+	s.log.WithField("temp_file", tempFilePath).Debug("Saving temporary report")
+
+	// Ensure directory exists
+	if err := os.MkdirAll(s.stateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create temp report directory: %w", err)
+	}
+
+	// Marshal report to JSON
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal temp report: %w", err)
+	}
+
+	// Write to file
+	if err := WriteFileAtomic(tempFilePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write temp report: %w", err)
+	}
+
+	s.log.WithField("temp_file", tempFilePath).Info("Temporary report saved successfully")
+	return nil
+}
+
+// LoadTempReport loads the most recent temporary report for the key's client pair and
+// enclave. The run ID is ignored since a recovered run resumes a previous run's report.
+func (s *service) LoadTempReport(ctx context.Context, key TempReportKey) (*Result, error) {
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	tempFiles, err := s.findTempReports(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find temp reports: %w", err)
+	}
+
+	if len(tempFiles) == 0 {
+		s.log.WithFields(logrus.Fields{
+			"network":   key.Network,
+			"el_client": key.ELClient,
+			"cl_client": key.CLClient,
+			"enclave":   key.Enclave,
+		}).Debug("No temporary reports found")
+		return nil, nil
+	}
+
+	// Load the most recent temp report
+	tempFilePath := tempFiles[0].Path
+	s.log.WithField("temp_file", tempFilePath).Debug("Loading temporary report")
+
+	data, err := os.ReadFile(tempFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read temp report: %w", err)
+	}
+
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal temp report: %w", err)
+	}
+
+	s.log.WithField("temp_file", tempFilePath).Info("Temporary report loaded successfully")
+	return &result, nil
+}
+
+// RemoveTempReport removes all temporary reports for the key's client pair and enclave
+func (s *service) RemoveTempReport(ctx context.Context, key TempReportKey) error {
+	// Held while removing so a concurrent save isn't removed right after it was written
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	tempFiles, err := s.findTempReports(key)
+	if err != nil {
+		return fmt.Errorf("failed to find temp reports: %w", err)
+	}
+
+	// Remove all matching temp files
+	for _, tempFile := range tempFiles {
+		if err := os.Remove(tempFile.Path); err != nil {
+			s.log.WithField("temp_file", tempFile.Path).WithError(err).Warn("Failed to remove temp report")
+		} else {
+			s.log.WithField("temp_file", tempFile.Path).Debug("Removed temporary report")
+		}
+	}
+
+	s.log.WithField("removed_count", len(tempFiles)).Info("Temporary reports cleaned up")
+	return nil
+}
+
+// ListTempReports lists all temporary reports in the state directory, newest first
+func (s *service) ListTempReports(ctx context.Context) ([]TempReportInfo, error) {
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	return s.listTempReports()
+}
+
+// listTempReports lists the temporary reports. Must be called with fileMu held.
+func (s *service) listTempReports() ([]TempReportInfo, error) {
+	stateDir := s.stateDir
+	entries, err := os.ReadDir(stateDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []TempReportInfo{}, nil
+		}
+		return nil, fmt.Errorf("failed to list temp reports: %w", err)
+	}
+
+	reports := make([]TempReportInfo, 0)
+	for _, entry := range entries {
+		key, ok := parseTempReportFilename(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed while listing
+		}
+
+		reports = append(reports, TempReportInfo{
+			TempReportKey: key,
+			Path:          filepath.Join(stateDir, entry.Name()),
+			Size:          info.Size(),
+			ModTime:       info.ModTime(),
+		})
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].ModTime.After(reports[j].ModTime) })
+
+	s.log.WithField("temp_reports_count", len(reports)).Debug("Listed temporary reports")
+	return reports, nil
+}
+
+// findTempReports finds temporary reports for the key's client pair and enclave, newest first.
+// Files written before enclave names were recorded match any enclave. Must be called with
+// fileMu held.
+func (s *service) findTempReports(key TempReportKey) ([]TempReportInfo, error) {
+	reports, err := s.listTempReports()
+	if err != nil {
+		return nil, err
+	}
+
+	network := sanitizeTempReportPart(key.Network)
+	elClient := sanitizeTempReportPart(key.ELClient)
+	clClient := sanitizeTempReportPart(key.CLClient)
+	enclave := sanitizeTempReportPart(key.Enclave)
+
+	matches := make([]TempReportInfo, 0)
+	for _, report := range reports {
+		if report.Network != network || report.ELClient != elClient || report.CLClient != clClient {
+			continue
+		}
+		if report.Enclave != "" && report.Enclave != enclave {
+			continue
+		}
+		matches = append(matches, report)
+	}
+
+	return matches, nil
+}
+
+// tempReportFilename generates the temporary report filename for a key
+func tempReportFilename(key TempReportKey) string {
+	return fmt.Sprintf("%s%s_%s_%s.%s.%s%s", tempReportPrefix,
+		sanitizeTempReportPart(key.Network),
+		sanitizeTempReportPart(key.ELClient),
+		sanitizeTempReportPart(key.CLClient),
+		sanitizeTempReportPart(key.Enclave),
+		sanitizeTempReportPart(key.RunID),
+		tempReportSuffix)
+}
+
+// parseTempReportFilename extracts the key from a temporary report filename. Legacy
+// filenames (sync-temp-{network}_{el}_{cl}.tmp.json) yield an empty enclave and run ID.
+func parseTempReportFilename(name string) (TempReportKey, bool) {
+	if !strings.HasPrefix(name, tempReportPrefix) || !strings.HasSuffix(name, tempReportSuffix) {
+		return TempReportKey{}, false
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(name, tempReportPrefix), tempReportSuffix), ".")
+	if len(parts) != 1 && len(parts) != 3 {
+		return TempReportKey{}, false
+	}
+
+	// Client types never contain underscores, so split the pair from the right
+	pair := strings.Split(parts[0], "_")
+	if len(pair) < 3 {
+		return TempReportKey{}, false
+	}
+
+	key := TempReportKey{
+		Network:  strings.Join(pair[:len(pair)-2], "_"),
+		ELClient: pair[len(pair)-2],
+		CLClient: pair[len(pair)-1],
+	}
+	if len(parts) == 3 {
+		key.Enclave = parts[1]
+		key.RunID = parts[2]
+	}
+
+	return key, true
+}
+
+// sanitizeTempReportPart replaces characters that would break temp filename parsing
+func sanitizeTempReportPart(part string) string {
+	if part == "" {
+		return "unknown"
+	}
+	return strings.NewReplacer(".", "-", "/", "-", string(filepath.Separator), "-").Replace(part)
+}
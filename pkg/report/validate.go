@@ -0,0 +1,77 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidReport = errors.New("invalid report")
+	errTrailingData  = errors.New("unexpected data after JSON value")
+)
+
+// reportStatuses are the sync statuses a report may have
+var reportStatuses = map[string]bool{"": true, "running": true, "success": true, "timeout": true, "cancelled": true, "error": true}
+
+// pathSafeName matches run IDs, networks and client types, which are used in report file names
+var pathSafeName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// isPathSafe reports whether a name can be used as a report file name component
+func isPathSafe(name string) bool {
+	return pathSafeName.MatchString(name) && !strings.HasPrefix(name, ".")
+}
+
+// ParseReport decodes and validates a main report and its progress file, as produced by
+// SaveReportToFiles, and returns the report with its progress entries
+func ParseReport(mainData, progressData []byte) (*Result, error) {
+	var result Result
+	if err := decodeStrict(mainData, &result); err != nil {
+		return nil, fmt.Errorf("%w: main file: %w", ErrInvalidReport, err)
+	}
+
+	var entries []SyncProgressEntry
+	if err := decodeStrict(progressData, &entries); err != nil {
+		return nil, fmt.Errorf("%w: progress file: %w", ErrInvalidReport, err)
+	}
+
+	status := &result.SyncStatus
+	if status.SyncProgressHash != "" && checksum(progressData) != status.SyncProgressHash {
+		return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, status.SyncProgressFile)
+	}
+
+	switch {
+	case !isPathSafe(result.RunID):
+		return nil, fmt.Errorf("%w: invalid run_id %q", ErrInvalidReport, result.RunID)
+	case !isPathSafe(result.Network):
+		return nil, fmt.Errorf("%w: invalid network %q", ErrInvalidReport, result.Network)
+	case !isPathSafe(result.ExecutionClientInfo.Type):
+		return nil, fmt.Errorf("%w: invalid execution client type %q", ErrInvalidReport, result.ExecutionClientInfo.Type)
+	case !isPathSafe(result.ConsensusClientInfo.Type):
+		return nil, fmt.Errorf("%w: invalid consensus client type %q", ErrInvalidReport, result.ConsensusClientInfo.Type)
+	case status.Start <= 0 || (status.End != 0 && status.End < status.Start):
+		return nil, fmt.Errorf("%w: invalid sync start/end", ErrInvalidReport)
+	case !reportStatuses[status.Status]:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidReport, status.Status)
+	}
+
+	status.SyncProgress = entries
+	status.EntriesCount = len(entries)
+	return &result, nil
+}
+
+// decodeStrict decodes a single JSON value, rejecting unknown fields and trailing data
+func decodeStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errTrailingData
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReport(t *testing.T) {
+	t.Parallel()
+
+	run := syntheticRun(2, 10)
+	run.ExecutionClientInfo.Type = "geth"
+	run.ConsensusClientInfo.Type = "teku"
+
+	progressData, err := json.Marshal(run.SyncStatus.SyncProgress)
+	require.NoError(t, err)
+
+	main := *run
+	main.SyncStatus.SyncProgress = nil
+	main.SyncStatus.SyncProgressHash = checksum(progressData)
+	mainData, err := json.Marshal(main)
+	require.NoError(t, err)
+
+	result, err := ParseReport(mainData, progressData)
+	require.NoError(t, err)
+	assert.Len(t, result.SyncStatus.SyncProgress, 11)
+	assert.Equal(t, 11, result.SyncStatus.EntriesCount)
+
+	_, err = ParseReport(mainData, []byte(`[{"t": 1}]`))
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	_, err = ParseReport([]byte(`{"run_id": "x", "unknown": 1}`), progressData)
+	require.ErrorIs(t, err, ErrInvalidReport)
+
+	for _, unsafe := range []func(r *Result){
+		func(r *Result) { r.RunID = "../escape" },
+		func(r *Result) { r.Network = "../hoodi" },
+		func(r *Result) { r.Network = "hoodi net" },
+		func(r *Result) { r.ExecutionClientInfo.Type = `geth\..` },
+		func(r *Result) { r.ConsensusClientInfo.Type = ".teku" },
+	} {
+		invalid := main
+		unsafe(&invalid)
+		mainData, err = json.Marshal(invalid)
+		require.NoError(t, err)
+		_, err = ParseReport(mainData, progressData)
+		require.ErrorIs(t, err, ErrInvalidReport)
+	}
+}
@@ -4,15 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"os"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/cenkalti/backoff.v1"
 )
 
+// ErrNoJob is returned by PollJob if no job is queued for the runner
+var ErrNoJob = errors.New("no job available")
+
 type Client struct {
 	serverURL  string
 	authToken  string
@@ -27,6 +34,17 @@ type Client struct {
 	// Keepalive tracking
 	keepaliveReq    *TestKeepaliveRequest
 	keepaliveTicker *time.Ticker
+
+	// Runner heartbeat, heartbeatReq is nil until StartHeartbeat is called and
+	// activeRuns are the tests reported in heartbeats
+	heartbeatMu  sync.Mutex
+	heartbeatReq *RunnerHeartbeatRequest
+	activeRuns   map[string]bool
+
+	// Remote cancellation, cancelled is closed once the server cancels the test
+	cancelOnce   sync.Once
+	cancelled    chan struct{}
+	cancelReason string
 }
 
 func NewClient(serverURL, authToken string, log logrus.FieldLogger) *Client {
@@ -37,6 +55,8 @@ func NewClient(serverURL, authToken string, log logrus.FieldLogger) *Client {
 		log:         log,
 		updateQueue: make(chan ProgressUpdateRequest, 100),
 		stopCh:      make(chan struct{}),
+		activeRuns:  make(map[string]bool),
+		cancelled:   make(chan struct{}),
 	}
 }
 
@@ -61,6 +81,8 @@ func (c *Client) Stop() {
 func (c *Client) ReportTestKeepAlive(ctx context.Context, req TestKeepaliveRequest) error {
 	c.runID = req.RunID
 
+	c.AddActiveRun(req.RunID)
+
 	// Store keepalive request for periodic updates
 	c.keepaliveReq = &req
 
@@ -70,7 +92,7 @@ func (c *Client) ReportTestKeepAlive(ctx context.Context, req TestKeepaliveReque
 		go c.processKeepalive(ctx)
 	}
 
-	return c.sendRequest(ctx, "POST", "/api/v1/tests/keepalive", c.keepaliveReq)
+	return c.sendControlRequest(ctx, "/api/v1/tests/keepalive", c.keepaliveReq)
 }
 
 func (c *Client) ReportProgress(metrics ProgressMetrics) {
@@ -91,11 +113,104 @@ func (c *Client) ReportProgress(metrics ProgressMetrics) {
 }
 
 func (c *Client) ReportTestComplete(ctx context.Context, req TestCompleteRequest) error {
+	c.RemoveActiveRun(c.runID)
+
 	return c.sendRequest(ctx, "POST", fmt.Sprintf("/api/v1/tests/%s/complete", c.runID), req)
 }
 
+// Cancelled is closed when the server cancels the reported test, as learned from
+// keepalive and progress responses
+func (c *Client) Cancelled() <-chan struct{} {
+	return c.cancelled
+}
+
+// CancelReason returns the reason the server gave for cancelling the test
+func (c *Client) CancelReason() string {
+	select {
+	case <-c.cancelled:
+		return c.cancelReason
+	default:
+		return ""
+	}
+}
+
+// AddActiveRun adds a test to the runs reported in heartbeats. Tests reported with
+// ReportTestKeepAlive are added automatically.
+func (c *Client) AddActiveRun(runID string) {
+	c.heartbeatMu.Lock()
+	defer c.heartbeatMu.Unlock()
+
+	c.activeRuns[runID] = true
+}
+
+// RemoveActiveRun removes a test from the runs reported in heartbeats
+func (c *Client) RemoveActiveRun(runID string) {
+	c.heartbeatMu.Lock()
+	defer c.heartbeatMu.Unlock()
+
+	delete(c.activeRuns, runID)
+}
+
+// StartHeartbeat registers the runner with the server and reports it every
+// DefaultHeartbeatInterval, along with the tests it is running, until the client stops.
+// Heartbeats carry a session ID unique to the process unless req sets one, so processes
+// sharing a runner ID don't replace each other's tests.
+func (c *Client) StartHeartbeat(ctx context.Context, req RunnerHeartbeatRequest) {
+	if req.SessionID == "" {
+		req.SessionID = fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
+	}
+
+	c.heartbeatMu.Lock()
+	c.heartbeatReq = &req
+	c.heartbeatMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(DefaultHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := c.sendHeartbeat(ctx); err != nil {
+				c.log.WithError(err).Warn("Failed to send runner heartbeat")
+			}
+
+			select {
+			case <-ticker.C:
+			case <-c.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
 // Internal methods
 func (c *Client) sendRequest(ctx context.Context, method, path string, body interface{}) error {
+	return c.sendRequestWithResponse(ctx, method, path, body, nil)
+}
+
+// sendControlRequest sends a keepalive or progress update and handles the server
+// cancelling the test
+func (c *Client) sendControlRequest(ctx context.Context, path string, body interface{}) error {
+	var resp struct {
+		Data TestControlResponse `json:"data"`
+	}
+	if err := c.sendRequestWithResponse(ctx, "POST", path, body, &resp); err != nil {
+		return err
+	}
+
+	if resp.Data.Cancel {
+		c.cancelOnce.Do(func() {
+			c.log.WithField("reason", resp.Data.CancelReason).Warn("Test cancelled by the server")
+			c.cancelReason = resp.Data.CancelReason
+			close(c.cancelled)
+		})
+	}
+	return nil
+}
+
+// sendRequestWithResponse sends a request, decoding the response body into out unless nil
+func (c *Client) sendRequestWithResponse(ctx context.Context, method, path string, body, out interface{}) error {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request: %w", err)
@@ -131,6 +246,13 @@ func (c *Client) sendRequest(ctx context.Context, method, path string, body inte
 			return &backoff.PermanentError{Err: lastErr} // Don't retry on client errors
 		}
 
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				lastErr = fmt.Errorf("failed to decode response: %w", err)
+				return &backoff.PermanentError{Err: lastErr}
+			}
+		}
+
 		return nil
 	}
 
@@ -153,7 +275,67 @@ func (c *Client) processUpdateQueue(ctx context.Context) {
 }
 
 func (c *Client) sendProgressUpdate(ctx context.Context, runID string, update ProgressUpdateRequest) error {
-	return c.sendRequest(ctx, "POST", fmt.Sprintf("/api/v1/tests/%s/progress", runID), update)
+	return c.sendControlRequest(ctx, fmt.Sprintf("/api/v1/tests/%s/progress", runID), update)
+}
+
+func (c *Client) sendHeartbeat(ctx context.Context) error {
+	c.heartbeatMu.Lock()
+	req := *c.heartbeatReq
+	req.Timestamp = time.Now().Unix()
+	req.RunIDs = make([]string, 0, len(c.activeRuns))
+	for runID := range c.activeRuns {
+		req.RunIDs = append(req.RunIDs, runID)
+	}
+	c.heartbeatMu.Unlock()
+	sort.Strings(req.RunIDs)
+
+	return c.sendRequest(ctx, "POST", "/api/v1/runners/heartbeat", req)
+}
+
+// PollJob waits up to req.Wait seconds for a job matching the runner. It returns
+// ErrNoJob if no job was queued in time.
+func (c *Client) PollJob(ctx context.Context, req JobPollRequest) (*JobAssignment, error) {
+	jsonBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.serverURL+"/api/v1/jobs/poll", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.authToken != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	// The server holds the request until a job is queued
+	httpClient := &http.Client{Timeout: time.Duration(req.Wait)*time.Second + c.httpClient.Timeout}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		return nil, ErrNoJob
+	case resp.StatusCode >= 400:
+		return nil, fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data *JobAssignment `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode job: %w", err)
+	}
+	return body.Data, nil
+}
+
+// CompleteJob reports the result of a job
+func (c *Client) CompleteJob(ctx context.Context, jobID string, req JobCompleteRequest) error {
+	return c.sendRequest(ctx, "POST", fmt.Sprintf("/api/v1/jobs/%s/complete", jobID), req)
 }
 
 func (c *Client) processKeepalive(ctx context.Context) {
@@ -163,7 +345,7 @@ func (c *Client) processKeepalive(ctx context.Context) {
 			if c.keepaliveReq != nil {
 				// Update timestamp for current keepalive
 				c.keepaliveReq.Timestamp = time.Now().Unix()
-				if err := c.sendRequest(ctx, "POST", "/api/v1/tests/keepalive", *c.keepaliveReq); err != nil {
+				if err := c.sendControlRequest(ctx, "/api/v1/tests/keepalive", *c.keepaliveReq); err != nil {
 					c.log.WithError(err).Warn("Failed to send keepalive")
 				}
 			}
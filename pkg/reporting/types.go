@@ -1,6 +1,13 @@
 package reporting
 
-import "github.com/ethpandaops/syncoor/pkg/sysinfo"
+import (
+	"time"
+
+	"github.com/ethpandaops/syncoor/pkg/sysinfo"
+)
+
+// DefaultHeartbeatInterval is how often runners report to the server
+const DefaultHeartbeatInterval = 30 * time.Second
 
 // TestKeepaliveRequest represents a keepalive request to maintain test connection
 type TestKeepaliveRequest struct {
@@ -13,6 +20,19 @@ type TestKeepaliveRequest struct {
 	EnclaveName string              `json:"enclave_name"`
 	SystemInfo  *sysinfo.SystemInfo `json:"system_info,omitempty"`
 	RunTimeout  int64               `json:"run_timeout,omitempty"`
+	RunnerID    string              `json:"runner_id,omitempty"`
+}
+
+// RunnerHeartbeatRequest registers a runner and reports the tests it is running
+type RunnerHeartbeatRequest struct {
+	RunnerID   string              `json:"runner_id"`
+	SessionID  string              `json:"session_id,omitempty"` // Process sending the heartbeat, run IDs are merged across sessions
+	Hostname   string              `json:"hostname"`
+	Timestamp  int64               `json:"timestamp"`
+	Labels     map[string]string   `json:"labels,omitempty"`
+	Capacity   int                 `json:"capacity"` // Number of tests the runner can run at once
+	RunIDs     []string            `json:"run_ids"`  // Tests currently running
+	SystemInfo *sysinfo.SystemInfo `json:"system_info,omitempty"`
 }
 
 type ClientConfig struct {
@@ -59,5 +79,58 @@ type TestCompleteRequest struct {
 	FinalBlock uint64 `json:"final_block"`
 	FinalSlot  uint64 `json:"final_slot"`
 	Success    bool   `json:"success"`
+	Cancelled  bool   `json:"cancelled,omitempty"` // Stopped because the server cancelled the test
+	TimedOut   bool   `json:"timed_out,omitempty"` // Stopped because the run timeout was reached
 	Error      string `json:"error,omitempty"`
 }
+
+// TestCancelRequest asks the server to stop a running test
+type TestCancelRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// TestControlResponse acknowledges keepalives and progress updates, telling the
+// runner whether the test was cancelled
+type TestControlResponse struct {
+	Status       string `json:"status"`
+	Cancel       bool   `json:"cancel,omitempty"`
+	CancelReason string `json:"cancel_reason,omitempty"`
+}
+
+// JobSpec describes a sync test queued on the server
+type JobSpec struct {
+	Network      string            `json:"network"`
+	ELClient     string            `json:"el_client"`
+	CLClient     string            `json:"cl_client"`
+	ELImage      string            `json:"el_image,omitempty"`
+	CLImage      string            `json:"cl_image,omitempty"`
+	ELExtraArgs  []string          `json:"el_extra_args,omitempty"`
+	CLExtraArgs  []string          `json:"cl_extra_args,omitempty"`
+	ELEnvVars    map[string]string `json:"el_env_vars,omitempty"`
+	CLEnvVars    map[string]string `json:"cl_env_vars,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`        // Labels of the test
+	RunnerLabels map[string]string `json:"runner_labels,omitempty"` // Labels a runner needs to run the job
+	RunTimeout   int64             `json:"run_timeout,omitempty"`   // Seconds, defaults to the agent's run timeout
+}
+
+// JobPollRequest asks the server for a job matching the labels of a runner
+type JobPollRequest struct {
+	RunnerID string            `json:"runner_id"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Wait     int64             `json:"wait,omitempty"` // Seconds to wait for a job
+}
+
+// JobAssignment is a job handed to a runner, to be run as the test RunID
+type JobAssignment struct {
+	JobID string  `json:"job_id"`
+	RunID string  `json:"run_id"`
+	Spec  JobSpec `json:"spec"`
+}
+
+// JobCompleteRequest reports the result of a job
+type JobCompleteRequest struct {
+	RunnerID  string `json:"runner_id"`
+	Success   bool   `json:"success"`
+	Cancelled bool   `json:"cancelled,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
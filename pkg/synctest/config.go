@@ -33,9 +33,14 @@ type Config struct {
 	Network               string
 	EnclaveName           string
 	ReportDir             string
+	StateDir              string // Directory for temporary recovery reports (default: ReportDir)
 	Labels                map[string]string
 	ServerURL             string // e.g., "https://api.syncoor.example"
 	ServerAuth            string // Bearer token for authentication
+	RunnerID              string // Stable identity of this runner on the server (default: hostname)
+	RunnerLabels          map[string]string
+	RunID                 string // Run ID reported to the server (default: generated)
+	ExternalHeartbeat     bool   // Runner heartbeats are sent by the caller, e.g. an agent running several tests
 	ClientLogs            bool   // Enable EL and CL client log output
 	Supernode             bool   // Enable supernode (should only be used with peerdas)
 	CheckpointSyncEnabled bool   // Enable checkpoint sync across the network
@@ -75,6 +80,11 @@ func (c *Config) SetDefaults() {
 		}
 	}
 
+	// Keep temporary reports next to the final reports unless configured otherwise
+	if c.StateDir == "" {
+		c.StateDir = c.ReportDir
+	}
+
 	// Set default client log levels if not specified
 	if c.ClientLogsLevelEL == "" {
 		c.ClientLogsLevelEL = "info"
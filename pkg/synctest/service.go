@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -26,8 +28,12 @@ import (
 	"github.com/ethpandaops/syncoor/pkg/sysinfo"
 )
 
-// ErrSyncTimeout is returned when the sync operation times out
-var ErrSyncTimeout = errors.New("sync operation timed out")
+var (
+	// ErrSyncTimeout is returned when the sync operation times out
+	ErrSyncTimeout = errors.New("sync operation timed out")
+	// ErrSyncCancelled is returned when the server cancels the sync test
+	ErrSyncCancelled = errors.New("sync operation cancelled")
+)
 
 // Service defines the interface for the sync test service
 type Service interface {
@@ -35,9 +41,15 @@ type Service interface {
 	Stop() error
 	WaitForSync(ctx context.Context) error
 
+	// SetSyncStatus updates the status recorded in the report
+	SetSyncStatus(ctx context.Context, status string, message string) error
+
 	// Recovery methods
 	EnableRecovery(recovery.Service)
 	SaveTempReport(ctx context.Context) error
+
+	// EnableReportStorage writes final reports to the given storage backend instead of the report directory
+	EnableReportStorage(report.Storage)
 }
 
 // service implements the Service interface
@@ -58,7 +70,7 @@ type service struct {
 
 	// Recovery support
 	recoveryService recovery.Service
-	tempReportSaved bool
+	tempReportSaved atomic.Bool
 	recoveredReport *report.Result
 
 	// Completion state
@@ -68,7 +80,8 @@ type service struct {
 	syncoorVersion string
 
 	// Run identification
-	runID string
+	runID    string
+	runnerID string
 
 	// Metrics Exporter Components
 	dockerManager    *docker.ContainerManager
@@ -96,6 +109,9 @@ func NewService(
 		reportService:  report.NewService(log),
 	}
 
+	// Temporary reports for recovery live in the state directory
+	svc.reportService.SetStateDir(cfg.StateDir)
+
 	// Store version for sysinfo
 	svc.syncoorVersion = version
 
@@ -264,7 +280,7 @@ func (s *service) Start(ctx context.Context) error {
 				s.log.Info("Enclave validation successful, attempting recovery")
 
 				// Load temporary report if available
-				if tempReport, err := s.reportService.LoadTempReport(ctx, s.cfg.Network, s.cfg.ELClient, s.cfg.CLClient); err != nil {
+				if tempReport, err := s.reportService.LoadTempReport(ctx, s.tempReportKey("")); err != nil {
 					s.log.WithError(err).Warn("Failed to load temp report, but continuing with recovery")
 				} else if tempReport != nil {
 					s.log.WithField("progress_entries", len(tempReport.SyncStatus.SyncProgress)).Info("Loaded temporary report for recovery")
@@ -329,9 +345,19 @@ func (s *service) Start(ctx context.Context) error {
 		}
 	}
 
+	// Register the runner before reporting the test, so the test is linked to it
+	heartbeat := s.runnerHeartbeat(systemInfo)
+	s.runnerID = heartbeat.RunnerID
+	if s.reportingClient != nil && !s.cfg.ExternalHeartbeat {
+		s.reportingClient.StartHeartbeat(ctx, heartbeat)
+	}
+
 	// Report test start if reporting client is configured
 	if s.reportingClient != nil {
-		s.runID = fmt.Sprintf("sync-test-%d-%s_%s_%s", time.Now().UnixNano(), s.cfg.Network, s.cfg.ELClient, s.cfg.CLClient)
+		s.runID = s.cfg.RunID
+		if s.runID == "" {
+			s.runID = fmt.Sprintf("sync-test-%d-%s_%s_%s", time.Now().UnixNano(), s.cfg.Network, s.cfg.ELClient, s.cfg.CLClient)
+		}
 		startReq := reporting.TestKeepaliveRequest{
 			RunID:     s.runID,
 			Timestamp: time.Now().Unix(),
@@ -352,6 +378,7 @@ func (s *service) Start(ctx context.Context) error {
 			EnclaveName: s.cfg.EnclaveName,
 			SystemInfo:  systemInfo,
 			RunTimeout:  int64(s.cfg.RunTimeout.Seconds()),
+			RunnerID:    s.runnerID,
 		}
 
 		if err := s.reportingClient.ReportTestKeepAlive(ctx, startReq); err != nil {
@@ -481,6 +508,7 @@ func (s *service) Start(ctx context.Context) error {
 			EnclaveName: s.cfg.EnclaveName,
 			SystemInfo:  systemInfo,
 			RunTimeout:  int64(s.cfg.RunTimeout.Seconds()),
+			RunnerID:    s.runnerID,
 		}
 
 		if err := s.reportingClient.ReportTestKeepAlive(ctx, updatedReq); err != nil {
@@ -549,9 +577,24 @@ func (s *service) WaitForSync(ctx context.Context) error {
 		timeoutCtx = ctx
 	}
 
+	// Tests reported to a server can be cancelled remotely
+	var cancelled <-chan struct{}
+	if s.reportingClient != nil {
+		cancelled = s.reportingClient.Cancelled()
+	}
+
 	// Start sync checking loop
 	for {
 		select {
+		case <-cancelled:
+			reason := s.reportingClient.CancelReason()
+			cancelMessage := fmt.Sprintf("Sync operation cancelled by the server: %s", reason)
+			logMessage := "Sync operation cancelled by the server, generating report with cancelled status"
+
+			// Use common finalization logic
+			s.finalizeSyncTest(ctx, "cancelled", cancelMessage, logMessage)
+
+			return fmt.Errorf("%w by the server: %s", ErrSyncCancelled, reason)
 		case <-timeoutCtx.Done():
 			// Check if it was a timeout or regular cancellation
 			if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
@@ -804,7 +847,7 @@ func (s *service) WaitForSync(ctx context.Context) error {
 
 			// Clean up temporary reports on successful completion
 			if s.recoveryService != nil {
-				if err := s.reportService.RemoveTempReport(ctx, s.cfg.Network, s.cfg.ELClient, s.cfg.CLClient); err != nil {
+				if err := s.reportService.RemoveTempReport(ctx, s.tempReportKey("")); err != nil {
 					s.log.WithError(err).Warn("Failed to clean up temporary reports")
 				} else {
 					s.log.Info("Cleaned up temporary reports after successful completion")
@@ -821,7 +864,12 @@ func (s *service) WaitForSync(ctx context.Context) error {
 			return nil
 		}
 
-		time.Sleep(s.cfg.CheckInterval)
+		// Wait for the next check, the loop handles cancellation
+		select {
+		case <-time.After(s.cfg.CheckInterval):
+		case <-cancelled:
+		case <-timeoutCtx.Done():
+		}
 	}
 }
 
@@ -835,6 +883,18 @@ func (s *service) EnableRecovery(recoveryService recovery.Service) {
 	s.log.Info("Recovery service enabled")
 }
 
+// EnableReportStorage configures the backend final reports are written to
+func (s *service) EnableReportStorage(storage report.Storage) {
+	s.reportService.SetStorage(storage)
+	s.log.WithField("storage", storage.String()).Info("Report storage enabled")
+}
+
+// SetSyncStatus updates the status recorded in the report. It is safe to call
+// concurrently with WaitForSync, e.g. from signal handlers.
+func (s *service) SetSyncStatus(ctx context.Context, status string, message string) error {
+	return s.reportService.SetSyncStatus(ctx, status, message)
+}
+
 // SaveTempReport saves a temporary report for recovery purposes
 func (s *service) SaveTempReport(ctx context.Context) error {
 	if s.recoveryService == nil {
@@ -861,15 +921,47 @@ func (s *service) SaveTempReport(ctx context.Context) error {
 	}
 
 	// Save temporary report with current progress
-	if err := s.reportService.SaveTempReport(ctx, currentReport); err != nil {
+	if err := s.reportService.SaveTempReport(ctx, s.tempReportKey(currentReport.RunID), currentReport); err != nil {
 		return fmt.Errorf("failed to save temp report: %w", err)
 	}
 
-	s.tempReportSaved = true
+	s.tempReportSaved.Store(true)
 	s.log.WithField("progress_entries", len(currentReport.SyncStatus.SyncProgress)).Info("Temporary report saved for recovery")
 	return nil
 }
 
+// tempReportKey identifies this run's temporary reports
+func (s *service) tempReportKey(runID string) report.TempReportKey {
+	return report.TempReportKey{
+		Network:  s.cfg.Network,
+		ELClient: s.cfg.ELClient,
+		CLClient: s.cfg.CLClient,
+		Enclave:  s.cfg.EnclaveName,
+		RunID:    runID,
+	}
+}
+
+// runnerHeartbeat describes this runner to the server. A sync run executes one test.
+func (s *service) runnerHeartbeat(systemInfo *sysinfo.SystemInfo) reporting.RunnerHeartbeatRequest {
+	hostname, err := os.Hostname()
+	if err != nil && systemInfo != nil {
+		hostname = systemInfo.Hostname
+	}
+
+	runnerID := s.cfg.RunnerID
+	if runnerID == "" {
+		runnerID = hostname
+	}
+
+	return reporting.RunnerHeartbeatRequest{
+		RunnerID:   runnerID,
+		Hostname:   hostname,
+		Labels:     s.cfg.RunnerLabels,
+		Capacity:   1,
+		SystemInfo: systemInfo,
+	}
+}
+
 // createBasicReport creates a basic report structure as fallback
 func (s *service) createBasicReport() *report.Result {
 	return &report.Result{
@@ -947,7 +1039,7 @@ func (s *service) checkContainerHealth(ctx context.Context, enclaveName, service
 	return nil
 }
 
-// finalizeSyncTest handles the common finalization steps for failed sync tests
+// finalizeSyncTest handles the common finalization steps for failed and cancelled sync tests
 func (s *service) finalizeSyncTest(ctx context.Context, status, errorMessage, logMessage string) {
 	s.log.Warn(logMessage)
 
@@ -964,6 +1056,8 @@ func (s *service) finalizeSyncTest(ctx context.Context, status, errorMessage, lo
 		completeReq := reporting.TestCompleteRequest{
 			Timestamp: time.Now().Unix(),
 			Success:   false,
+			Cancelled: status == "cancelled",
+			TimedOut:  status == "timeout",
 			Error:     errorMessage,
 		}
 
@@ -996,7 +1090,7 @@ func (s *service) finalizeSyncTest(ctx context.Context, status, errorMessage, lo
 
 	// Clean up temporary reports
 	if s.recoveryService != nil {
-		if err := s.reportService.RemoveTempReport(ctx, s.cfg.Network, s.cfg.ELClient, s.cfg.CLClient); err != nil {
+		if err := s.reportService.RemoveTempReport(ctx, s.tempReportKey("")); err != nil {
 			s.log.WithError(err).Warn("Failed to clean up temporary reports")
 		}
 	}
@@ -4,6 +4,7 @@ import Dashboard from './pages/Dashboard'
 import TestList from './pages/TestList'
 import TestDetails from './pages/TestDetails'
 import DumpExplorer from './pages/DumpExplorer'
+import Runners from './pages/Runners'
 
 function App() {
   return (
@@ -15,6 +16,7 @@ function App() {
           <Route path="/test/:directory/:id" element={<TestDetails />} />
           <Route path="/test/:id" element={<TestDetails />} />
           <Route path="/dump/:id" element={<DumpExplorer />} />
+          <Route path="/runners" element={<Runners />} />
         </Routes>
       </Layout>
     </ErrorBoundary>
@@ -9,6 +9,7 @@ interface LiveTestExpandedProps {
   getClientLogo: (clientType: string) => string;
   capitalizeClient: (clientType: string) => string;
   onUpdateDetail: (testKey: string) => void;
+  onCancel?: (testKey: string) => Promise<void>;
 }
 
 const LiveTestExpanded: React.FC<LiveTestExpandedProps> = ({
@@ -17,10 +18,28 @@ const LiveTestExpanded: React.FC<LiveTestExpandedProps> = ({
   detail,
   getClientLogo,
   capitalizeClient,
-  onUpdateDetail
+  onUpdateDetail,
+  onCancel
 }) => {
   const [lastFetch, setLastFetch] = useState<Date>(new Date());
   const [secondsSinceLastFetch, setSecondsSinceLastFetch] = useState(0);
+  const [cancelling, setCancelling] = useState(false);
+  const [cancelError, setCancelError] = useState<string>();
+
+  const handleCancel = async () => {
+    if (!onCancel || !window.confirm(`Cancel test ${test.run_id}?`)) {
+      return;
+    }
+    setCancelling(true);
+    setCancelError(undefined);
+    try {
+      await onCancel(testKey);
+    } catch (error) {
+      setCancelError(error instanceof Error ? error.message : 'Failed to cancel test');
+    } finally {
+      setCancelling(false);
+    }
+  };
 
   // Auto-update for running tests
   useEffect(() => {
@@ -82,6 +101,25 @@ const LiveTestExpanded: React.FC<LiveTestExpandedProps> = ({
 
   return (
     <div className="space-y-4">
+      {/* Remote cancellation of running tests */}
+      {test.is_running && (testDetail.cancel_requested ? (
+        <div className="text-sm text-amber-700 dark:text-amber-300 bg-amber-50 dark:bg-amber-900/20 border border-amber-200 dark:border-amber-800 rounded-lg p-3">
+          Cancel requested{testDetail.cancel_reason ? `: ${testDetail.cancel_reason}` : ''}. Waiting for the runner to stop the test.
+        </div>
+      ) : onCancel && (
+        <div className="flex items-center gap-3">
+          <button
+            type="button"
+            onClick={handleCancel}
+            disabled={cancelling}
+            className="text-sm px-3 py-1.5 rounded-md border border-red-300 dark:border-red-700 text-red-700 dark:text-red-300 hover:bg-red-50 dark:hover:bg-red-900/20 disabled:opacity-50"
+          >
+            {cancelling ? 'Cancelling...' : 'Cancel test'}
+          </button>
+          {cancelError && <span className="text-sm text-red-600 dark:text-red-400">{cancelError}</span>}
+        </div>
+      ))}
+
       {/* Error information if present */}
       {testDetail.error && (
         <div className="space-y-2">
@@ -6,7 +6,7 @@ import { Button } from './ui/button';
 import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from './ui/select';
 import { SyncoorApiEndpoint } from '../types/config';
 import { TestSummary, TestDetail, HealthResponse } from '../types/syncoor';
-import { fetchSyncoorTests, fetchSyncoorHealth, fetchSyncoorTestDetail } from '../lib/syncoorApi';
+import { fetchSyncoorTests, fetchSyncoorHealth, fetchSyncoorTestDetail, cancelSyncoorTest } from '../lib/syncoorApi';
 import { useSearchParams } from 'react-router-dom';
 import LiveTestExpanded from './LiveTestExpanded';
 
@@ -182,6 +182,17 @@ const LiveTests: React.FC<LiveTestsProps> = ({ endpoints, className }) => {
     }
   }, [endpointData]);
 
+  const cancelTest = useCallback(async (testKey: string) => {
+    const [endpointUrl, runId] = testKey.split('|||');
+    const endpoint = endpointData.find(d => d.endpoint.url === endpointUrl);
+    if (!endpoint || !runId) {
+      return;
+    }
+
+    await cancelSyncoorTest(endpoint.endpoint, runId, 'Cancelled from the UI');
+    await updateTestDetail(testKey);
+  }, [endpointData, updateTestDetail]);
+
   // Safety check for endpoints
   if (!endpoints) {
     return (
@@ -864,6 +875,7 @@ const LiveTests: React.FC<LiveTestsProps> = ({ endpoints, className }) => {
                                       getClientLogo={getClientLogo}
                                       capitalizeClient={capitalizeClient}
                                       onUpdateDetail={updateTestDetail}
+                                      onCancel={cancelTest}
                                     />
                                   </td>
                                 </tr>
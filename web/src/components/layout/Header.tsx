@@ -10,6 +10,7 @@ import { Switch } from '../ui/switch';
 const navigationItems = [
   { name: 'Dashboard', href: '/' },
   { name: 'Tests', href: '/tests' },
+  { name: 'Runners', href: '/runners' },
 ];
 
 export function Header() {
@@ -1,7 +1,7 @@
 import { useQuery } from '@tanstack/react-query';
-import { fetchIndex } from '../lib/api';
+import { fetchIndex, fetchIndexShard } from '../lib/api';
 import { Directory } from '../types/config';
-import { IndexEntry } from '../types/report';
+import { IndexEntry, IndexShard } from '../types/report';
 import { TestFilterParams, PaginationParams } from '../types/api';
 
 /**
@@ -34,8 +34,11 @@ export interface UseReportsParams {
 export interface UseReportsResult {
   /** Array of report entries */
   data: ReportEntry[];
-  /** Total count before pagination */
+  /** Total count before pagination. With sharded indexes, entries of shard pages that
+   *  weren't loaded are counted without the client, date and directory filters applied. */
   total: number;
+  /** Networks of all indexed reports, including the ones in shards that weren't loaded */
+  networks: string[];
   /** Current page */
   page: number;
   /** Items per page */
@@ -132,6 +135,86 @@ function sortReports(reports: ReportEntry[], sortBy: string, sortOrder: 'asc' |
   });
 }
 
+/**
+ * Position in the pages of an index shard, which are ordered newest first
+ */
+interface ShardCursor {
+  directory: Directory;
+  shard: IndexShard;
+  /** Index of the next file to load */
+  next: number;
+  /** Number of entries loaded so far */
+  loaded: number;
+  /** Timestamp of the oldest loaded entry, no unloaded entry of the shard is newer */
+  frontier: number;
+}
+
+/**
+ * Adds source directory information to index entries
+ */
+function withSource(entries: IndexEntry[], directory: Directory): ReportEntry[] {
+  return entries.map(entry => ({
+    ...entry,
+    source_directory: directory.name,
+    source_display_name: directory.displayName || directory.name,
+    source_url: directory.url,
+  }));
+}
+
+function hasPendingPages(cursor: ShardCursor): boolean {
+  return cursor.next < cursor.shard.files.length;
+}
+
+/**
+ * Loads the next page of a shard into reports
+ */
+async function loadShardPage(cursor: ShardCursor, reports: ReportEntry[]): Promise<void> {
+  const entries = await fetchIndexShard(cursor.directory, cursor.shard.files[cursor.next]);
+  cursor.next++;
+  cursor.loaded += entries.length;
+  if (entries.length > 0) {
+    cursor.frontier = Number(entries[entries.length - 1].timestamp);
+  }
+  reports.push(...withSource(entries, cursor.directory));
+}
+
+/**
+ * Loads the shard pages needed for the requested page. For the default newest first
+ * order, pages are merged lazily: the page of the shard with the newest unloaded entries
+ * is loaded until the requested page is filled with entries that no unloaded entry can
+ * precede. Other orders need every page of the shards.
+ */
+async function loadShards(
+  cursors: ShardCursor[],
+  reports: ReportEntry[],
+  filters: TestFilterParams,
+  pagination: PaginationParams
+): Promise<void> {
+  const newestFirst = (pagination.sortBy || 'timestamp') === 'timestamp' && (pagination.sortOrder || 'desc') === 'desc';
+  if (!newestFirst) {
+    await Promise.all(cursors.map(async cursor => {
+      while (hasPendingPages(cursor)) {
+        await loadShardPage(cursor, reports);
+      }
+    }));
+    return;
+  }
+
+  const needed = pagination.page * pagination.limit;
+  for (;;) {
+    const pending = cursors.filter(hasPendingPages);
+    if (pending.length === 0) {
+      return;
+    }
+    const newest = pending.reduce((a, b) => (b.frontier > a.frontier ? b : a));
+    const settled = filterReports(reports, filters).filter(report => Number(report.timestamp) >= newest.frontier);
+    if (settled.length >= needed) {
+      return;
+    }
+    await loadShardPage(newest, reports);
+  }
+}
+
 /**
  * Hook to fetch indexes from all enabled directories and aggregate results
  * @param params - Configuration and filtering parameters
@@ -167,21 +250,31 @@ export function useReports(params: UseReportsParams): UseReportsResult {
       
       const results = await Promise.all(indexPromises);
       
-      // Aggregate all entries with source directory information
+      // Aggregate all entries with source directory information, only the shards
+      // matching the directory and network filters are loaded
       const allReports: ReportEntry[] = [];
+      const cursors: ShardCursor[] = [];
+      const networks = new Set<string>();
       
       results.forEach(result => {
-        if (result.index) {
-          const reportsWithSource = result.index.entries.map(entry => ({
-            ...entry,
-            source_directory: result.directory.name,
-            source_display_name: result.directory.displayName || result.directory.name,
-            source_url: result.directory.url,
-          }));
-          allReports.push(...reportsWithSource);
+        if (!result.index) {
+          return;
         }
+        result.index.entries.forEach(entry => networks.add(entry.network));
+        result.index.shards?.forEach(shard => networks.add(shard.network));
+        if (filters.directory && result.directory.name !== filters.directory) {
+          return;
+        }
+        allReports.push(...withSource(result.index.entries, result.directory));
+        result.index.shards?.forEach(shard => {
+          if (!filters.network || shard.network === filters.network) {
+            cursors.push({ directory: result.directory, shard, next: 0, loaded: 0, frontier: shard.latest });
+          }
+        });
       });
       
+      await loadShards(cursors, allReports, filters, pagination);
+      
       // Sort by timestamp (newest first) by default
       let sortedReports = sortReports(
         allReports,
@@ -195,12 +288,16 @@ export function useReports(params: UseReportsParams): UseReportsResult {
       // Apply pagination
       const paginatedReports = paginateReports(filteredReports, pagination);
       
+      const unloaded = cursors.reduce((sum, cursor) => sum + Math.max(cursor.shard.entries_count - cursor.loaded, 0), 0);
+      const total = filteredReports.length + unloaded;
+      
       return {
         reports: paginatedReports,
-        total: filteredReports.length,
+        total,
+        networks: [...networks].sort(),
         page: pagination.page,
         limit: pagination.limit,
-        totalPages: Math.ceil(filteredReports.length / pagination.limit),
+        totalPages: Math.ceil(total / pagination.limit),
       };
     },
     enabled: enabledDirectories.length > 0,
@@ -215,6 +312,7 @@ export function useReports(params: UseReportsParams): UseReportsResult {
   return {
     data: query.data?.reports || [],
     total: query.data?.total || 0,
+    networks: query.data?.networks || [],
     page: query.data?.page || 1,
     limit: query.data?.limit || 20,
     totalPages: query.data?.totalPages || 0,
@@ -110,7 +110,8 @@ function buildUrl(directory: Directory, path: string): string {
 }
 
 /**
- * Fetches the report index from a directory
+ * Fetches the report index from a directory. For sharded indexes this is only the
+ * manifest, the entries of its shards are fetched with fetchIndexShard.
  * @param directory - The directory to fetch from
  * @returns The report index
  * @throws ApiError if the fetch fails (except for 404, which returns empty index)
@@ -151,6 +152,23 @@ export async function fetchIndex(directory: Directory): Promise<ReportIndex> {
   }
 }
 
+/**
+ * Fetches the entries of one shard file listed in an index manifest
+ * @param directory - The directory to fetch from
+ * @param file - The shard file, relative to the directory
+ * @returns The entries of the shard file, newest first
+ * @throws ApiError if the fetch fails
+ */
+export async function fetchIndexShard(directory: Directory, file: string): Promise<ReportIndex['entries']> {
+  const url = `${buildUrl(directory, file)}?_t=${Date.now()}`;
+  const response = await fetchWithRetry(url);
+  const data = await response.json();
+  if (!data || !Array.isArray(data.entries)) {
+    throw new ApiError('Invalid index shard: entries must be an array', undefined, undefined, url);
+  }
+  return data.entries as ReportIndex['entries'];
+}
+
 /**
  * Fetches progress data from a directory
  * @param directory - The directory to fetch from
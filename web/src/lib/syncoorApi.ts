@@ -3,7 +3,8 @@ import {
   SyncoorApiResponse, 
   TestListResponse, 
   TestDetail, 
-  HealthResponse 
+  HealthResponse,
+  RunnerListResponse
 } from '../types/syncoor';
 
 /**
@@ -105,6 +106,13 @@ function buildSyncoorUrl(endpoint: SyncoorApiEndpoint, path: string): string {
   return `${baseUrl}${cleanPath}`;
 }
 
+/**
+ * Returns the authorization header for endpoints that require a read token
+ */
+function authHeaders(endpoint: SyncoorApiEndpoint): HeadersInit {
+  return endpoint.token ? { 'Authorization': `Bearer ${endpoint.token}` } : {};
+}
+
 /**
  * Fetches test list from a syncoor endpoint
  */
@@ -112,7 +120,7 @@ export async function fetchSyncoorTests(endpoint: SyncoorApiEndpoint): Promise<T
   const url = buildSyncoorUrl(endpoint, '/api/v1/tests');
   
   try {
-    const response = await syncoorFetchWithRetry(url);
+    const response = await syncoorFetchWithRetry(url, { headers: authHeaders(endpoint) });
     const apiResponse: SyncoorApiResponse<TestListResponse> = await response.json();
     
     if (apiResponse.error) {
@@ -156,7 +164,7 @@ export async function fetchSyncoorTestDetail(endpoint: SyncoorApiEndpoint, runId
   const url = buildSyncoorUrl(endpoint, `/api/v1/tests/${runId}`);
   
   try {
-    const response = await syncoorFetchWithRetry(url);
+    const response = await syncoorFetchWithRetry(url, { headers: authHeaders(endpoint) });
     const apiResponse: SyncoorApiResponse<TestDetail> = await response.json();
     
     if (apiResponse.error) {
@@ -196,7 +204,7 @@ export async function fetchSyncoorHealth(endpoint: SyncoorApiEndpoint): Promise<
   const url = buildSyncoorUrl(endpoint, '/health');
   
   try {
-    const response = await syncoorFetchWithRetry(url);
+    const response = await syncoorFetchWithRetry(url, { headers: authHeaders(endpoint) });
     const apiResponse: SyncoorApiResponse<HealthResponse> = await response.json();
     
     if (apiResponse.error) {
@@ -227,4 +235,70 @@ export async function fetchSyncoorHealth(endpoint: SyncoorApiEndpoint): Promise<
       endpoint.name
     );
   }
-}
\ No newline at end of file
+}
+
+/**
+ * Fetches the registered runners from a syncoor endpoint
+ */
+export async function fetchSyncoorRunners(endpoint: SyncoorApiEndpoint): Promise<RunnerListResponse> {
+  const url = buildSyncoorUrl(endpoint, '/api/v1/runners');
+
+  try {
+    const response = await syncoorFetchWithRetry(url, { headers: authHeaders(endpoint) });
+    const apiResponse: SyncoorApiResponse<RunnerListResponse> = await response.json();
+
+    if (apiResponse.error) {
+      throw new SyncoorApiError(
+        `API error: ${apiResponse.error.message}`,
+        undefined,
+        undefined,
+        url,
+        endpoint.name
+      );
+    }
+
+    // Basic validation
+    if (!apiResponse.data || !Array.isArray(apiResponse.data.runners)) {
+      throw new SyncoorApiError('Invalid runners data: expected runners array', undefined, undefined, url, endpoint.name);
+    }
+
+    return apiResponse.data;
+  } catch (error) {
+    if (error instanceof SyncoorApiError) {
+      throw error;
+    }
+    throw new SyncoorApiError(
+      `Failed to fetch runners from ${endpoint.name}: ${error instanceof Error ? error.message : 'Unknown error'}`,
+      undefined,
+      undefined,
+      url,
+      endpoint.name
+    );
+  }
+}
+
+/**
+ * Cancels a running test, its runner stops it with its next progress update.
+ * Requires an endpoint token with the job-write scope.
+ */
+export async function cancelSyncoorTest(endpoint: SyncoorApiEndpoint, runId: string, reason?: string): Promise<void> {
+  const url = buildSyncoorUrl(endpoint, `/api/v1/tests/${runId}/cancel`);
+
+  const response = await fetch(url, {
+    method: 'POST',
+    headers: { ...DEFAULT_HEADERS, ...authHeaders(endpoint) },
+    body: JSON.stringify({ reason }),
+    signal: AbortSignal.timeout(10000),
+  });
+
+  if (!response.ok) {
+    const apiResponse: SyncoorApiResponse<unknown> | undefined = await response.json().catch(() => undefined);
+    throw new SyncoorApiError(
+      `Failed to cancel test on ${endpoint.name}: ${apiResponse?.error?.message ?? `HTTP ${response.status}`}`,
+      response.status,
+      response.statusText,
+      url,
+      endpoint.name
+    );
+  }
+}
@@ -1,4 +1,4 @@
-import { ClientInfo, ProgressEntry } from '../types/report';
+import { ClientInfo, ProgressEntry, ProgressSeries } from '../types/report';
 import { clsx, type ClassValue } from 'clsx';
 import React from 'react';
 
@@ -616,4 +616,30 @@ export function getStatusIcon(status?: string): React.ReactElement | null {
     default:
       return null;
   }
-}
\ No newline at end of file
+}
+
+/**
+ * Maximum number of progress entries loaded for the progress charts
+ */
+export const MAX_CHART_POINTS = 5000;
+
+/**
+ * Selects the finest progress series with at most maxPoints entries, falling back to
+ * the coarsest series when none fit
+ * @param series - Progress series of an index entry
+ * @param maxPoints - Maximum number of entries
+ * @returns The selected series, or undefined for reports without series
+ */
+export function selectProgressSeries(series: ProgressSeries[] | undefined, maxPoints: number): ProgressSeries | undefined {
+  let best: ProgressSeries | undefined;
+  let coarsest: ProgressSeries | undefined;
+  for (const candidate of series ?? []) {
+    if (!coarsest || candidate.interval > coarsest.interval) {
+      coarsest = candidate;
+    }
+    if (candidate.entries_count <= maxPoints && (!best || candidate.interval < best.interval)) {
+      best = candidate;
+    }
+  }
+  return best ?? coarsest;
+}
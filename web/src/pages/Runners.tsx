@@ -0,0 +1,127 @@
+import { useQueries } from '@tanstack/react-query';
+import { useConfig } from '../hooks/useConfig';
+import { Card, CardContent, CardHeader, CardTitle } from '../components/ui/card';
+import { Badge } from '../components/ui/badge';
+import { fetchSyncoorRunners } from '../lib/syncoorApi';
+import { Runner, RunnerRun } from '../types/syncoor';
+
+const RUNNER_STATUS_VARIANTS = {
+  idle: 'secondary',
+  busy: 'success',
+  offline: 'destructive',
+} as const;
+
+const RUN_STATUS_VARIANTS = {
+  running: 'default',
+  completed: 'success',
+  failed: 'destructive',
+  cancelled: 'secondary',
+  orphaned: 'warning',
+} as const;
+
+/**
+ * Formats how long ago a timestamp was
+ */
+function formatAge(timestamp: string): string {
+  const seconds = Math.max(0, Math.floor((Date.now() - new Date(timestamp).getTime()) / 1000));
+  if (seconds < 60) return `${seconds}s ago`;
+  if (seconds < 3600) return `${Math.floor(seconds / 60)}m ago`;
+  if (seconds < 86400) return `${Math.floor(seconds / 3600)}h ago`;
+  return `${Math.floor(seconds / 86400)}d ago`;
+}
+
+function RunHistory({ runs }: { runs: RunnerRun[] }) {
+  if (runs.length === 0) {
+    return <p className="text-sm text-muted-foreground">No tests yet</p>;
+  }
+
+  return (
+    <div className="space-y-1">
+      {runs.slice(0, 5).map(run => (
+        <div key={run.run_id} className="flex items-center gap-2 text-sm">
+          <Badge variant={RUN_STATUS_VARIANTS[run.status] ?? 'outline'}>{run.status}</Badge>
+          <span className="font-mono truncate" title={run.run_id}>
+            {run.network && run.el_client ? `${run.network} ${run.el_client}/${run.cl_client}` : run.run_id}
+          </span>
+          <span className="text-muted-foreground ml-auto whitespace-nowrap">{formatAge(run.start_time)}</span>
+        </div>
+      ))}
+    </div>
+  );
+}
+
+function RunnerCard({ runner }: { runner: Runner }) {
+  return (
+    <Card>
+      <CardHeader className="pb-2">
+        <div className="flex items-center justify-between gap-2">
+          <CardTitle className="text-base font-mono truncate" title={runner.id}>{runner.id}</CardTitle>
+          <Badge variant={RUNNER_STATUS_VARIANTS[runner.status] ?? 'outline'}>{runner.status}</Badge>
+        </div>
+        <p className="text-sm text-muted-foreground">
+          {runner.hostname} · capacity {runner.capacity} · last heartbeat {formatAge(runner.last_heartbeat)}
+        </p>
+        {runner.labels && Object.keys(runner.labels).length > 0 && (
+          <div className="flex flex-wrap gap-1 pt-1">
+            {Object.entries(runner.labels).map(([key, value]) => (
+              <Badge key={key} variant="outline">{key}={value}</Badge>
+            ))}
+          </div>
+        )}
+      </CardHeader>
+      <CardContent>
+        <RunHistory runs={runner.history} />
+      </CardContent>
+    </Card>
+  );
+}
+
+export default function Runners() {
+  const { data: config, isLoading: configLoading } = useConfig();
+  const endpoints = (config?.syncoorApiEndpoints ?? []).filter(endpoint => endpoint.enabled);
+
+  const results = useQueries({
+    queries: endpoints.map(endpoint => ({
+      queryKey: ['syncoor-runners', endpoint.url],
+      queryFn: () => fetchSyncoorRunners(endpoint),
+      refetchInterval: 30000,
+    })),
+  });
+
+  if (configLoading) {
+    return <div className="text-center py-12 text-muted-foreground">Loading configuration...</div>;
+  }
+
+  if (endpoints.length === 0) {
+    return <div className="text-center py-12 text-muted-foreground">No syncoor API endpoints configured</div>;
+  }
+
+  return (
+    <div className="space-y-8">
+      <h1 className="text-3xl font-bold">Runners</h1>
+      {endpoints.map((endpoint, i) => {
+        const { data, error, isLoading } = results[i];
+        return (
+          <section key={endpoint.url} className="space-y-4">
+            <div className="flex items-center gap-3">
+              <h2 className="text-xl font-semibold">{endpoint.name}</h2>
+              {data && (
+                <span className="text-sm text-muted-foreground">
+                  {data.online_count} of {data.total_count} online
+                </span>
+              )}
+            </div>
+            {isLoading && <p className="text-muted-foreground">Loading runners...</p>}
+            {error && <p className="text-destructive">{error.message}</p>}
+            {data && data.runners.length === 0 && (
+              <p className="text-muted-foreground">No runners have registered</p>
+            )}
+            <div className="grid gap-4 md:grid-cols-2 xl:grid-cols-3">
+              {data?.runners.map(runner => <RunnerCard key={runner.id} runner={runner} />)}
+            </div>
+          </section>
+        );
+      })}
+    </div>
+  );
+}
@@ -7,7 +7,7 @@ import { useConfig } from '../hooks/useConfig';
 import { useReports } from '../hooks/useReports';
 import { useProgressData } from '../hooks/useProgressData';
 import { useMainReport } from '../hooks/useMainReport';
-import { formatDuration, formatTimestamp, getStatusBadgeInfo, getStatusIcon, formatBytes } from '../lib/utils';
+import { formatDuration, formatTimestamp, getStatusBadgeInfo, getStatusIcon, formatBytes, selectProgressSeries, MAX_CHART_POINTS } from '../lib/utils';
 import { extractFileFromDump } from '../lib/api';
 import { SystemInformation } from '../components/SystemInformation';
 import { GithubActionsInfo } from '../components/GithubActionsInfo';
@@ -35,8 +35,10 @@ export default function TestDetails() {
   // Find the specific test report (do this before hooks to ensure consistent hook calls)
   const testReport = reports?.find(report => report.run_id === id);
 
-  // Fetch progress data - always call the hook but conditionally enable it
-  const progressUrl = testReport ? `${testReport.source_url}${testReport.progress_file}` : '';
+  // Fetch progress data - always call the hook but conditionally enable it. Long runs
+  // load a downsampled series so the charts stay responsive.
+  const progressFile = selectProgressSeries(testReport?.progress_series, MAX_CHART_POINTS)?.file ?? testReport?.progress_file;
+  const progressUrl = testReport ? `${testReport.source_url}${progressFile}` : '';
   const { data: progressData, isLoading: progressLoading, error: progressError } = useProgressData({
     progressUrl,
     enabled: !!testReport && !configLoading && !reportsLoading
@@ -69,12 +69,15 @@ export default function TestList() {
     const dir = config.directories.find(d => d.name === directoryName);
     return dir?.displayName || dir?.name || directoryName;
   };
+  // Directory and network filters are passed on, so only the index shards they select are loaded
   const {
     data: allReports,
+    networks,
     isLoading: reportsLoading,
     error: reportsError
   } = useReports({
     directories: config?.directories || [],
+    filters: { directory: directoryFilter || undefined, network: networkFilter || undefined },
     pagination: { page: 1, limit: 10000, sortBy: 'timestamp', sortOrder: 'desc' }
   });
   
@@ -83,13 +86,13 @@ export default function TestList() {
     if (!allReports) return { directories: [], networks: [], elClients: [], clClients: [], statuses: [] };
     
     return {
-      directories: [...new Set(allReports.map(r => r.source_directory))].sort(),
-      networks: [...new Set(allReports.map(r => r.network))].sort(),
+      directories: (config?.directories || []).filter(d => d.enabled).map(d => d.name).sort(),
+      networks,
       elClients: [...new Set(allReports.map(r => r.execution_client_info.type))].sort(),
       clClients: [...new Set(allReports.map(r => r.consensus_client_info.type))].sort(),
       statuses: [...new Set(allReports.map(r => r.sync_info.status || 'success'))].sort()
     };
-  }, [allReports]);
+  }, [allReports, config, networks]);
   
   // Filter and sort reports client-side
   const filteredAndSortedReports = useMemo(() => {
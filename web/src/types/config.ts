@@ -26,6 +26,8 @@ export interface SyncoorApiEndpoint {
   url: string;
   /** Whether this endpoint is enabled */
   enabled: boolean;
+  /** Token with the read scope, for servers requiring read authentication */
+  token?: string;
 }
 
 /**
@@ -42,6 +42,8 @@ export interface SyncInfo {
   slot: number;
   /** Number of progress entries recorded */
   entries_count: number;
+  /** Oldest progress entries missing from the report */
+  entries_dropped?: number;
   /** Last progress entry recorded */
   last_entry?: ProgressEntry;
 }
@@ -72,6 +74,22 @@ export interface IndexEntry {
   progress_file: string;
   /** Path to the dump file (if exists) */
   dump_file?: string;
+  /** Progress files at full and downsampled resolutions */
+  progress_series?: ProgressSeries[];
+}
+
+/**
+ * Reference to a progress file at a specific resolution
+ */
+export interface ProgressSeries {
+  /** Resolution name (e.g., 'full', '1m', '10m') */
+  resolution: string;
+  /** Bucket size in seconds (0 for the full series) */
+  interval: number;
+  /** Path to the progress file */
+  file: string;
+  /** Number of entries in the series */
+  entries_count: number;
 }
 
 /**
@@ -118,6 +136,87 @@ export interface ReportIndex {
   generated: string;
   /** Array of test run entries */
   entries: IndexEntry[];
+  /** Report pairs that failed the integrity check */
+  corrupted?: CorruptedReport[];
+  /** Shard files holding the entries when the index is a manifest */
+  shards?: IndexShard[];
+  /** Precomputed statistics per network and client pair */
+  aggregates?: IndexAggregate[];
+}
+
+/**
+ * Compact reference to an indexed run
+ */
+export interface IndexRunSummary {
+  run_id: string;
+  timestamp: number;
+  status?: string;
+  /** Sync duration in seconds */
+  duration: number;
+  block: number;
+  /** Final EL disk usage in bytes */
+  disk_el: number;
+  /** Final CL disk usage in bytes */
+  disk_cl: number;
+  main_file: string;
+}
+
+/**
+ * Statistics for all indexed runs of a network and client pair
+ */
+export interface IndexAggregate {
+  network: string;
+  el_client: string;
+  cl_client: string;
+  runs: number;
+  successes: number;
+  /** Fraction of successful runs, 0 to 1 */
+  success_rate: number;
+  /** Median duration of successful runs in seconds */
+  median_duration: number;
+  /** 90th percentile duration of successful runs in seconds */
+  p90_duration: number;
+  /** Median final EL disk usage of successful runs in bytes */
+  median_disk_el: number;
+  /** Median final CL disk usage of successful runs in bytes */
+  median_disk_cl: number;
+  last_success?: IndexRunSummary;
+  /** Most recent runs, oldest first */
+  trend: IndexRunSummary[];
+  /** Percent change of the median duration between the older and newer half of the trend */
+  duration_change: number;
+}
+
+/**
+ * Shard of a sharded index, listed in the top-level manifest
+ */
+export interface IndexShard {
+  /** Shard name */
+  name: string;
+  /** Network of the entries in this shard */
+  network: string;
+  /** Execution client, when sharded by client */
+  el_client?: string;
+  /** Consensus client, when sharded by client */
+  cl_client?: string;
+  /** Number of entries across all files */
+  entries_count: number;
+  /** Timestamp of the newest entry */
+  latest: number;
+  /** Shard files relative to the manifest, newest entries first */
+  files: string[];
+}
+
+/**
+ * Report pair that failed the index integrity check
+ */
+export interface CorruptedReport {
+  /** Path to the main report file */
+  main_file: string;
+  /** Path to the progress file */
+  progress_file?: string;
+  /** Reason the report was flagged */
+  reason: string;
 }
 
 /**
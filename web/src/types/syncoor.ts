@@ -81,7 +81,11 @@ export interface TestSummary {
   current_metrics?: ProgressMetrics;
   system_info?: SystemInfo;
   run_timeout?: number;
+  runner_id?: string;
   error?: string;
+  cancel_requested?: boolean;
+  cancel_reason?: string;
+  cancelled?: boolean;
 }
 
 /**
@@ -124,6 +128,11 @@ export interface TestDetail {
   run_timeout?: number;
   end_time?: string;
   error?: string;
+  cancel_requested?: boolean;
+  cancel_reason?: string;
+  cancel_requested_at?: string;
+  cancel_requested_by?: string;
+  cancelled?: boolean;
 }
 
 /**
@@ -133,6 +142,7 @@ export interface TestListResponse {
   tests: TestSummary[];
   total_count: number;
   active_count: number;
+  next_cursor?: string;
 }
 
 /**
@@ -142,4 +152,45 @@ export interface HealthResponse {
   status: string;
   active_tests: number;
   total_tests: number;
-}
\ No newline at end of file
+}
+
+/**
+ * Test executed by a runner
+ */
+export interface RunnerRun {
+  run_id: string;
+  network?: string;
+  el_client?: string;
+  cl_client?: string;
+  start_time: string;
+  end_time?: string;
+  status: 'running' | 'completed' | 'failed' | 'cancelled' | 'orphaned';
+  error?: string;
+}
+
+/**
+ * Runner registered through heartbeats
+ */
+export interface Runner {
+  id: string;
+  hostname: string;
+  labels?: Record<string, string>;
+  capacity: number;
+  system_info?: SystemInfo;
+  registered_by?: string;
+  first_seen: string;
+  last_heartbeat: string;
+  status: 'idle' | 'busy' | 'offline';
+  online: boolean;
+  current_run_ids: string[];
+  history: RunnerRun[];
+}
+
+/**
+ * Runner list response
+ */
+export interface RunnerListResponse {
+  runners: Runner[];
+  total_count: number;
+  online_count: number;
+}